@@ -0,0 +1,440 @@
+//
+// CMS / PKCS#7 SignedData with ECDSA signers
+//
+// https://datatracker.ietf.org/doc/html/rfc5652#section-5
+// https://datatracker.ietf.org/doc/html/rfc5753#section-3
+//
+
+package cms
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+type SignedData struct {
+	ContentType  []int64
+	Content      []byte // nil when the content is detached
+	Certificates [][]byte
+	SignerInfos  []SignerInfo
+}
+
+type SignerInfo struct {
+	// IssuerAndSerialNumber holds the hex DER signer identifier taken from the signer's
+	// certificate. It is empty when the signer is identified by SubjectKeyIdentifier instead.
+	IssuerAndSerialNumber string
+	SubjectKeyIdentifier  []byte
+	DigestAlgorithm       []int64
+	ContentType           []int64
+	MessageDigest         []byte
+	SigningTime           time.Time
+	Signature             signature.Signature
+
+	// signedAttributes holds the hex DER of the signed attributes, without their
+	// [0] IMPLICIT tag, exactly as they were signed. Empty when there are none.
+	signedAttributes string
+}
+
+// Sign wraps content in an attached SignedData signed by privateKey. The signer is identified
+// by certificate (DER) when given, and by the subject key identifier of its public key otherwise.
+// Signed attributes carry the content type, message digest and signing time.
+func Sign(content string, privateKey *privatekey.PrivateKey, certificate []byte, hashfunc ...utils.HashFunc) SignedData {
+	signedData := SignDetached(content, privateKey, certificate, hashfunc...)
+	signedData.Content = []byte(content)
+	return signedData
+}
+
+// SignDetached is like Sign but leaves the content out of the SignedData
+func SignDetached(content string, privateKey *privatekey.PrivateKey, certificate []byte, hashfunc ...utils.HashFunc) SignedData {
+	hf := utils.HashFunc(utils.Sha256)
+	if len(hashfunc) > 0 {
+		hf = hashfunc[0]
+	}
+	digestAlgorithm := digestOid(hf)
+
+	h := hf()
+	h.Write([]byte(content))
+	signerInfo := SignerInfo{
+		DigestAlgorithm: digestAlgorithm,
+		ContentType:     _dataOid,
+		MessageDigest:   h.Sum(nil),
+		SigningTime:     time.Now().UTC().Truncate(time.Second),
+	}
+	if certificate != nil {
+		signerInfo.IssuerAndSerialNumber = issuerAndSerialNumber(certificate)
+	} else {
+		publicKey := privateKey.PublicKey()
+		signerInfo.SubjectKeyIdentifier = subjectKeyIdentifier(&publicKey)
+	}
+	signerInfo.signedAttributes = encodeSignedAttributes(signerInfo)
+	signedAttributes := utils.ByteStringFromHex(utils.EncodePrimitive(utils.Set, signerInfo.signedAttributes))
+	signerInfo.Signature = ecdsa.Sign(string(signedAttributes), privateKey, hf)
+
+	signedData := SignedData{
+		ContentType: _dataOid,
+		SignerInfos: []SignerInfo{signerInfo},
+	}
+	if certificate != nil {
+		signedData.Certificates = [][]byte{certificate}
+	}
+	return signedData
+}
+
+// Verify checks that a signer of signedData holding publicKey signed its content, or
+// detachedContent when the content is detached. Certificates are not validated: the
+// caller decides which keys to trust, e.g. via CertificatePublicKey.
+func Verify(signedData SignedData, publicKey *publickey.PublicKey, detachedContent ...string) bool {
+	content := signedData.Content
+	if len(detachedContent) > 0 {
+		content = []byte(detachedContent[0])
+	}
+	if content == nil {
+		return false
+	}
+	for _, signerInfo := range signedData.SignerInfos {
+		if verifySigner(signerInfo, signedData.ContentType, content, publicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func verifySigner(signerInfo SignerInfo, contentType []int64, content []byte, publicKey *publickey.PublicKey) bool {
+	hf, ok := digestFuncs[oidKey(signerInfo.DigestAlgorithm)]
+	if !ok {
+		return false
+	}
+	if signerInfo.signedAttributes == "" {
+		return ecdsa.Verify(string(content), signerInfo.Signature, publicKey, hf)
+	}
+
+	h := hf()
+	h.Write(content)
+	if !curve.IsOidEqual(signerInfo.ContentType, contentType) || !bytes.Equal(signerInfo.MessageDigest, h.Sum(nil)) {
+		return false
+	}
+	signedAttributes := utils.ByteStringFromHex(utils.EncodePrimitive(utils.Set, signerInfo.signedAttributes))
+	return ecdsa.Verify(string(signedAttributes), signerInfo.Signature, publicKey, hf)
+}
+
+func (obj SignedData) ToDer() []byte {
+	var digestAlgorithms, signerInfos string
+	seen := map[string]bool{}
+	for _, signerInfo := range obj.SignerInfos {
+		algorithm := utils.EncodeConstructed(utils.EncodePrimitive(utils.Object, signerInfo.DigestAlgorithm))
+		if !seen[algorithm] {
+			digestAlgorithms += algorithm
+			seen[algorithm] = true
+		}
+		signerInfos += signerInfo.encode()
+	}
+
+	encapsulatedContent := utils.EncodePrimitive(utils.Object, obj.ContentType)
+	if obj.Content != nil {
+		octetString := utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(obj.Content))
+		encapsulatedContent += utils.EncodeTag(_explicit0Tag, octetString)
+	}
+
+	fields := []string{
+		utils.EncodePrimitive(utils.Integer, big.NewInt(obj.version())),
+		utils.EncodePrimitive(utils.Set, digestAlgorithms),
+		utils.EncodeConstructed(encapsulatedContent),
+	}
+	if len(obj.Certificates) > 0 {
+		certificates := ""
+		for _, certificate := range obj.Certificates {
+			certificates += utils.HexFromByteString(certificate)
+		}
+		fields = append(fields, utils.EncodeTag(_implicit0Tag, certificates))
+	}
+	fields = append(fields, utils.EncodePrimitive(utils.Set, signerInfos))
+
+	hexadecimal := utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Object, _signedDataOid),
+		utils.EncodeTag(_explicit0Tag, utils.EncodeConstructed(fields...)),
+	)
+	return utils.ByteStringFromHex(hexadecimal)
+}
+
+func (obj SignedData) ToPem() string {
+	return utils.CreatePem(utils.Base64FromByteString(obj.ToDer()), toPemTemplate)
+}
+
+func FromPem(pem string) SignedData {
+	content := utils.GetPemContent(pem, fromPemTemplate)
+	return FromDer(utils.ByteStringFromBase64(content))
+}
+
+// FromDer parses a ContentInfo holding SignedData. BER input, such as the indefinite
+// length output of streaming encoders, is accepted too.
+func FromDer(data []byte) SignedData {
+	contentInfo := utils.ParseElements(utils.BerToDer(utils.HexFromByteString(data)))
+	if len(contentInfo) != 1 || contentInfo[0].Tag != _sequenceTag {
+		panic("CMS content should be a single ContentInfo SEQUENCE")
+	}
+	fields := utils.ParseElements(contentInfo[0].Content)
+	if len(fields) != 2 || fields[1].Tag != _explicit0Tag {
+		panic("CMS ContentInfo should have a content type and an explicit [0] content")
+	}
+	if contentType := utils.ParseOid(fields[0].Content); !curve.IsOidEqual(contentType, _signedDataOid) {
+		panic(fmt.Sprintf("CMS content type should be signedData %v, but %v was found instead", _signedDataOid, contentType))
+	}
+
+	inner := utils.ParseElements(fields[1].Content)
+	if len(inner) != 1 || inner[0].Tag != _sequenceTag {
+		panic("CMS SignedData should be a SEQUENCE")
+	}
+	elements := utils.ParseElements(inner[0].Content)
+	if len(elements) < 4 {
+		panic("CMS SignedData is missing fields")
+	}
+
+	signedData := SignedData{}
+	encapsulatedContent := utils.ParseElements(elements[2].Content)
+	signedData.ContentType = utils.ParseOid(encapsulatedContent[0].Content)
+	if len(encapsulatedContent) > 1 {
+		octetString := utils.ParseElements(encapsulatedContent[1].Content)[0]
+		signedData.Content = utils.ByteStringFromHex(octetString.Content)
+	}
+
+	for _, element := range elements[3 : len(elements)-1] {
+		if element.Tag != _implicit0Tag {
+			continue // crls
+		}
+		for _, certificate := range utils.ParseElements(element.Content) {
+			signedData.Certificates = append(signedData.Certificates, utils.ByteStringFromHex(certificate.Raw))
+		}
+	}
+
+	signerInfos := elements[len(elements)-1]
+	if signerInfos.Tag != _setTag {
+		panic("CMS SignedData should end with a SET of SignerInfos")
+	}
+	for _, element := range utils.ParseElements(signerInfos.Content) {
+		signedData.SignerInfos = append(signedData.SignerInfos, parseSignerInfo(element.Content))
+	}
+	return signedData
+}
+
+// CertificatePublicKey extracts the subject public key of an X.509 certificate (DER)
+func CertificatePublicKey(certificate []byte) publickey.PublicKey {
+	fields := certificateFields(certificate)
+	return publickey.FromDer(utils.ByteStringFromHex(fields[5].Raw))
+}
+
+func (obj SignedData) version() int64 {
+	for _, signerInfo := range obj.SignerInfos {
+		if signerInfo.IssuerAndSerialNumber == "" {
+			return 3
+		}
+	}
+	return 1
+}
+
+func (obj SignerInfo) encode() string {
+	version := int64(1)
+	identifier := obj.IssuerAndSerialNumber
+	if identifier == "" {
+		version = 3
+		identifier = utils.EncodeTag(_subjectKeyIdentifierTag, utils.HexFromByteString(obj.SubjectKeyIdentifier))
+	}
+
+	fields := []string{
+		utils.EncodePrimitive(utils.Integer, big.NewInt(version)),
+		identifier,
+		utils.EncodeConstructed(utils.EncodePrimitive(utils.Object, obj.DigestAlgorithm)),
+	}
+	if obj.signedAttributes != "" {
+		fields = append(fields, utils.EncodeTag(_implicit0Tag, obj.signedAttributes))
+	}
+	fields = append(fields,
+		utils.EncodeConstructed(utils.EncodePrimitive(utils.Object, signatureOid(obj.DigestAlgorithm))),
+		utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(obj.Signature.ToDer())),
+	)
+	return utils.EncodeConstructed(fields...)
+}
+
+func parseSignerInfo(hexadecimal string) SignerInfo {
+	elements := utils.ParseElements(hexadecimal)
+	if len(elements) < 5 {
+		panic("CMS SignerInfo is missing fields")
+	}
+
+	signerInfo := SignerInfo{}
+	switch elements[1].Tag {
+	case _sequenceTag:
+		signerInfo.IssuerAndSerialNumber = elements[1].Raw
+	case _subjectKeyIdentifierTag:
+		signerInfo.SubjectKeyIdentifier = utils.ByteStringFromHex(elements[1].Content)
+	default:
+		panic(fmt.Sprintf("Unknown CMS signer identifier tag %v", elements[1].Tag))
+	}
+	signerInfo.DigestAlgorithm = utils.ParseOid(utils.ParseElements(elements[2].Content)[0].Content)
+
+	index := 3
+	if elements[index].Tag == _implicit0Tag {
+		signerInfo.signedAttributes = elements[index].Content
+		parseSignedAttributes(&signerInfo)
+		index++
+	}
+	if len(elements) < index+2 {
+		panic("CMS SignerInfo is missing its signature")
+	}
+	signerInfo.Signature = signature.FromDer(utils.ByteStringFromHex(elements[index+1].Content))
+	return signerInfo
+}
+
+func parseSignedAttributes(signerInfo *SignerInfo) {
+	for _, attribute := range utils.ParseElements(signerInfo.signedAttributes) {
+		fields := utils.ParseElements(attribute.Content)
+		if len(fields) != 2 {
+			panic("CMS attributes should have a type and a SET of values")
+		}
+		values := utils.ParseElements(fields[1].Content)
+		if len(values) == 0 {
+			continue
+		}
+		value := values[0]
+		switch oidKey(utils.ParseOid(fields[0].Content)) {
+		case oidKey(_contentTypeAttributeOid):
+			signerInfo.ContentType = utils.ParseOid(value.Content)
+		case oidKey(_messageDigestAttributeOid):
+			signerInfo.MessageDigest = utils.ByteStringFromHex(value.Content)
+		case oidKey(_signingTimeAttributeOid):
+			signerInfo.SigningTime = utils.Parse(value.Raw)[0].(time.Time)
+		}
+	}
+}
+
+// encodeSignedAttributes returns the DER SET OF content for the signer's attributes,
+// sorted by encoding as DER requires
+func encodeSignedAttributes(signerInfo SignerInfo) string {
+	signingTime := utils.EncodePrimitive(utils.GeneralizedTime, utils.HexFromByteString([]byte(signerInfo.SigningTime.Format("20060102150405Z"))))
+	if year := signerInfo.SigningTime.Year(); year >= 1950 && year < 2050 {
+		signingTime = utils.EncodePrimitive(utils.UtcTime, utils.HexFromByteString([]byte(signerInfo.SigningTime.Format("060102150405Z"))))
+	}
+	attributes := []string{
+		encodeAttribute(_contentTypeAttributeOid, utils.EncodePrimitive(utils.Object, signerInfo.ContentType)),
+		encodeAttribute(_signingTimeAttributeOid, signingTime),
+		encodeAttribute(_messageDigestAttributeOid, utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(signerInfo.MessageDigest))),
+	}
+	sort.Strings(attributes)
+	result := ""
+	for _, attribute := range attributes {
+		result += attribute
+	}
+	return result
+}
+
+func encodeAttribute(oid []int64, value string) string {
+	return utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Object, oid),
+		utils.EncodePrimitive(utils.Set, value),
+	)
+}
+
+// certificateFields returns the TBSCertificate fields after the optional version:
+// serialNumber, signature, issuer, validity, subject, subjectPublicKeyInfo, ...
+func certificateFields(certificate []byte) []utils.Element {
+	outer := utils.ParseElements(utils.HexFromByteString(certificate))
+	if len(outer) != 1 || outer[0].Tag != _sequenceTag {
+		panic("X.509 certificate should be a SEQUENCE")
+	}
+	tbsCertificate := utils.ParseElements(utils.ParseElements(outer[0].Content)[0].Content)
+	if tbsCertificate[0].Tag == _explicit0Tag {
+		tbsCertificate = tbsCertificate[1:]
+	}
+	if len(tbsCertificate) < 6 {
+		panic("X.509 certificate is missing fields")
+	}
+	return tbsCertificate
+}
+
+func issuerAndSerialNumber(certificate []byte) string {
+	fields := certificateFields(certificate)
+	return utils.EncodeConstructed(fields[2].Raw, fields[0].Raw)
+}
+
+// subjectKeyIdentifier follows RFC 5280 §4.2.1.2 method 1: the SHA-1 of the public key bits
+func subjectKeyIdentifier(publicKey *publickey.PublicKey) []byte {
+	digest := sha1.Sum(utils.ByteStringFromHex(publicKey.ToString(true)[2:]))
+	return digest[:]
+}
+
+func digestOid(hf utils.HashFunc) []int64 {
+	switch hf().Size() {
+	case 32:
+		return _sha256Oid
+	case 48:
+		return _sha384Oid
+	case 64:
+		return _sha512Oid
+	}
+	panic("CMS signatures support SHA-256, SHA-384 and SHA-512 only")
+}
+
+func signatureOid(digestAlgorithm []int64) []int64 {
+	switch oidKey(digestAlgorithm) {
+	case oidKey(_sha384Oid):
+		return _ecdsaWithSha384Oid
+	case oidKey(_sha512Oid):
+		return _ecdsaWithSha512Oid
+	}
+	return _ecdsaWithSha256Oid
+}
+
+func oidKey(oid []int64) string {
+	return fmt.Sprint(oid)
+}
+
+const (
+	_sequenceTag             = "30"
+	_setTag                  = "31"
+	_explicit0Tag            = "a0"
+	_implicit0Tag            = "a0"
+	_subjectKeyIdentifierTag = "80"
+)
+
+var _dataOid = []int64{1, 2, 840, 113549, 1, 7, 1}
+var _signedDataOid = []int64{1, 2, 840, 113549, 1, 7, 2}
+
+var _contentTypeAttributeOid = []int64{1, 2, 840, 113549, 1, 9, 3}
+var _messageDigestAttributeOid = []int64{1, 2, 840, 113549, 1, 9, 4}
+var _signingTimeAttributeOid = []int64{1, 2, 840, 113549, 1, 9, 5}
+
+var _sha256Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 1}
+var _sha384Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 2}
+var _sha512Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+var _ecdsaWithSha256Oid = []int64{1, 2, 840, 10045, 4, 3, 2}
+var _ecdsaWithSha384Oid = []int64{1, 2, 840, 10045, 4, 3, 3}
+var _ecdsaWithSha512Oid = []int64{1, 2, 840, 10045, 4, 3, 4}
+
+var digestFuncs = map[string]utils.HashFunc{
+	oidKey(_sha256Oid): utils.Sha256,
+	oidKey(_sha384Oid): utils.Sha384,
+	oidKey(_sha512Oid): utils.Sha512,
+}
+
+const toPemTemplate = `
+-----BEGIN CMS-----
+{content}
+-----END CMS-----
+`
+
+const fromPemTemplate = `
+^\s*-----BEGIN (?:CMS|PKCS7)-----
+{content}
+-----END (?:CMS|PKCS7)-----\s*$
+`
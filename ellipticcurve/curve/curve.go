@@ -12,6 +12,7 @@ import (
 
 	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
 )
 
 type CurveFp struct {
@@ -19,13 +20,14 @@ type CurveFp struct {
 	B          *big.Int
 	P          *big.Int
 	N          *big.Int
+	H          *big.Int // cofactor: the curve has H*N points
 	G          point.Point
 	Name       string
 	NistName   string
 	Oid        []int64
 	NBitLength int
 
-	// GeneratorCache caches the precomputed NAF table of G, populated
+	// GeneratorCache caches the precomputed comb table of G, populated
 	// lazily by ecmath.MultiplyGenerator.
 	GeneratorCache *ecmath.GeneratorCache
 
@@ -33,10 +35,23 @@ type CurveFp struct {
 	// one (e.g. secp256k1). nil for curves without an efficient
 	// endomorphism (e.g. prime256v1); those fall back to Shamir+JSF.
 	GLVParams *ecmath.GLVParams
+
+	// Binary is the GF(2^m) field of binary curves (e.g. sect233k1), whose
+	// equation is y^2 + x*y = x^3 + A*x^2 + B and whose P is the reduction
+	// polynomial. nil for curves over prime fields.
+	Binary *ecmath.BinaryField
+
+	// Backend runs the scalar multiplications on fixed-size limbs for curves that
+	// have one (secp256k1 and P-256), with the same results. nil for the others, which
+	// use the generic big.Int arithmetic.
+	Backend ecmath.Backend
 }
 
 // Contains verifies if the point p is on the curve
 func (obj CurveFp) Contains(p point.Point) bool {
+	if obj.Binary != nil {
+		return obj.Binary.Contains(p, obj.A, obj.B)
+	}
 	zero := big.NewInt(0)
 	max := new(big.Int).Sub(obj.P, big.NewInt(1))
 	if p.X.Cmp(zero) < 0 || p.X.Cmp(max) > 0 {
@@ -56,13 +71,20 @@ func (obj CurveFp) Contains(p point.Point) bool {
 	return result.Cmp(zero) == 0
 }
 
-// Length returns the byte length of the curve order
+// Length returns the byte length of the field elements, i.e. of each point coordinate
+// (66 for secp521r1, whose 521 bits don't fill the last byte)
 func (obj CurveFp) Length() int {
-	return (1 + len(fmt.Sprintf("%x", obj.N))) / 2
+	if obj.Binary != nil {
+		return (obj.Binary.M + 7) / 8
+	}
+	return (obj.P.BitLen() + 7) / 8
 }
 
 // Y computes the y coordinate given x and parity (isEven)
 func (obj CurveFp) Y(x *big.Int, isEven bool) *big.Int {
+	if obj.Binary != nil {
+		panic(fmt.Sprintf("Compressed points aren't supported on the binary curve %v", obj.Name))
+	}
 	// ySquared = (x^3 + A*x + B) % P
 	ySquared := new(big.Int).Exp(x, big.NewInt(3), obj.P)
 	ax := new(big.Int).Mul(obj.A, x)
@@ -77,6 +99,93 @@ func (obj CurveFp) Y(x *big.Int, isEven bool) *big.Int {
 	return y
 }
 
+// Multiply returns k*p on the curve
+func (obj CurveFp) Multiply(p point.Point, k *big.Int) point.Point {
+	if obj.Backend != nil {
+		return obj.Backend.Multiply(p, k)
+	}
+	if obj.Binary != nil {
+		return ecmath.BinaryMultiply(p, k, obj.N, obj.A, obj.B, obj.Binary)
+	}
+	return ecmath.Multiply(p, k, obj.N, obj.A, obj.P)
+}
+
+// MultiplyGenerator returns k*G, using the cached generator table on prime curves. A
+// blinded k + m*N, with 0 < m < 2^ecmath.ScalarBlindingBits, runs unreduced. The
+// optional z, a nonzero field element such as RandomFieldElement, randomizes the
+// projective coordinates of the computation without changing its result.
+func (obj CurveFp) MultiplyGenerator(k *big.Int, z ...*big.Int) point.Point {
+	if obj.Backend != nil {
+		return obj.Backend.MultiplyGenerator(k, z...)
+	}
+	if obj.Binary != nil {
+		return ecmath.BinaryMultiplyGenerator(obj.G, k, obj.N, obj.A, obj.B, obj.Binary, z...)
+	}
+	return ecmath.MultiplyGenerator(ecmath.MultiplyGeneratorParams{
+		G:          obj.G,
+		A:          obj.A,
+		P:          obj.P,
+		N:          obj.N,
+		NBitLength: obj.NBitLength,
+		Cache:      obj.GeneratorCache,
+	}, k, z...)
+}
+
+// RandomFieldElement returns a random nonzero element of the field of the curve
+func (obj CurveFp) RandomFieldElement() *big.Int {
+	if obj.Binary != nil {
+		return utils.Between(big.NewInt(1), new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(obj.Binary.M)), big.NewInt(1)))
+	}
+	return utils.Between(big.NewInt(1), new(big.Int).Sub(obj.P, big.NewInt(1)))
+}
+
+// MultiplyAndAdd returns k1*p1 + k2*p2, as needed to verify signatures
+func (obj CurveFp) MultiplyAndAdd(p1 point.Point, k1 *big.Int, p2 point.Point, k2 *big.Int) point.Point {
+	if obj.Backend != nil {
+		return obj.Backend.MultiplyAndAdd(p1, k1, p2, k2)
+	}
+	if obj.Binary != nil {
+		return ecmath.BinaryMultiplyAndAdd(p1, k1, p2, k2, obj.N, obj.A, obj.B, obj.Binary)
+	}
+	return ecmath.MultiplyAndAddWithGLV(p1, k1, p2, k2, obj.N, obj.A, obj.P, obj.GLVParams)
+}
+
+// Prepare precomputes the multiples of p used by MultiplyGeneratorAndAdd
+func (obj CurveFp) Prepare(p point.Point) ecmath.PreparedPoint {
+	if obj.Backend != nil {
+		return obj.Backend.Prepare(p)
+	}
+	if obj.Binary != nil {
+		return unpreparedPoint{p}
+	}
+	return ecmath.Prepare(p, obj.A, obj.P, obj.GLVParams)
+}
+
+// MultiplyGeneratorAndAdd returns k1*G + k2*q like MultiplyAndAdd, reusing the
+// precomputed multiples of G and of q
+func (obj CurveFp) MultiplyGeneratorAndAdd(k1 *big.Int, q ecmath.PreparedPoint, k2 *big.Int) point.Point {
+	if obj.Backend != nil {
+		return obj.Backend.MultiplyGeneratorAndAdd(k1, q, k2)
+	}
+	if obj.Binary != nil {
+		return obj.MultiplyAndAdd(obj.G, k1, q.Point(), k2)
+	}
+	cache := obj.GeneratorCache
+	cache.PreparedOnce.Do(func() {
+		cache.Prepared = ecmath.Prepare(obj.G, obj.A, obj.P, obj.GLVParams)
+	})
+	return ecmath.MultiplyAndAddPrepared(cache.Prepared, k1, q, k2, obj.N, obj.A, obj.P)
+}
+
+// unpreparedPoint is the PreparedPoint of binary curves, which keep no tables
+type unpreparedPoint struct {
+	point point.Point
+}
+
+func (obj unpreparedPoint) Point() point.Point {
+	return obj.point
+}
+
 func New(name string, AHex string, BHex string, PHex string, NHex string, GxHex string, GyHex string, oid []int64, nistName string) CurveFp {
 	return NewWithGLV(name, AHex, BHex, PHex, NHex, GxHex, GyHex, oid, nistName, nil)
 }
@@ -84,26 +193,47 @@ func New(name string, AHex string, BHex string, PHex string, NHex string, GxHex
 // NewWithGLV is like New but also sets the GLV endomorphism parameters for
 // curves that support one (e.g. secp256k1). Pass nil for curves without.
 func NewWithGLV(name string, AHex string, BHex string, PHex string, NHex string, GxHex string, GyHex string, oid []int64, nistName string, glv *ecmath.GLVParams) CurveFp {
-	A, _ := new(big.Int).SetString(AHex, 0)
-	B, _ := new(big.Int).SetString(BHex, 0)
-	P, _ := new(big.Int).SetString(PHex, 0)
-	N, _ := new(big.Int).SetString(NHex, 0)
-	Gx, _ := new(big.Int).SetString(GxHex, 0)
-	Gy, _ := new(big.Int).SetString(GyHex, 0)
+	c, err := parse(name, AHex, BHex, PHex, NHex, GxHex, GyHex, oid, nistName, glv)
+	if err != nil {
+		panic(err.Error())
+	}
+	return c
+}
+
+func parse(name string, AHex string, BHex string, PHex string, NHex string, GxHex string, GyHex string, oid []int64, nistName string, glv *ecmath.GLVParams) (CurveFp, error) {
+	values := map[string]string{"A": AHex, "B": BHex, "P": PHex, "N": NHex, "Gx": GxHex, "Gy": GyHex}
+	parsed := map[string]*big.Int{}
+	for _, parameter := range []string{"A", "B", "P", "N", "Gx", "Gy"} {
+		value, ok := new(big.Int).SetString(values[parameter], 0)
+		if !ok {
+			return CurveFp{}, fmt.Errorf("curve %v: %v = %q isn't a valid number", name, parameter, values[parameter])
+		}
+		parsed[parameter] = value
+	}
 
 	return CurveFp{
 		Name:           name,
 		NistName:       nistName,
-		A:              A,
-		B:              B,
-		P:              P,
-		N:              N,
-		G:              point.Point{X: Gx, Y: Gy, Z: big.NewInt(0)},
+		A:              parsed["A"],
+		B:              parsed["B"],
+		P:              parsed["P"],
+		N:              parsed["N"],
+		H:              nearestCofactor(parsed["P"], parsed["N"]),
+		G:              point.Point{X: parsed["Gx"], Y: parsed["Gy"]},
 		Oid:            oid,
-		NBitLength:     N.BitLen(),
+		NBitLength:     parsed["N"].BitLen(),
 		GeneratorCache: &ecmath.GeneratorCache{},
 		GLVParams:      glv,
-	}
+	}, nil
+}
+
+// NewBinary creates a curve y^2 + x*y = x^3 + A*x^2 + B over GF(2^m), where PolyHex is
+// the reduction polynomial of the field
+func NewBinary(name string, AHex string, BHex string, PolyHex string, NHex string, GxHex string, GyHex string, oid []int64, nistName string) CurveFp {
+	c := New(name, AHex, BHex, PolyHex, NHex, GxHex, GyHex, oid, nistName)
+	c.Binary = ecmath.NewBinaryField(c.P)
+	c.H = nearestCofactor(c.fieldOrder(), c.N)
+	return c
 }
 
 func hexBig(s string) *big.Int {
@@ -123,7 +253,7 @@ var secp256k1GLVParams = &ecmath.GLVParams{
 	B2:   hexBig("0x3086d221a7d46bcde86c90e49284eb15"),
 }
 
-var Secp256k1 = NewWithGLV(
+var Secp256k1 = withSecp256k1Backend(NewWithGLV(
 	"secp256k1",
 	"0x0000000000000000000000000000000000000000000000000000000000000000",
 	"0x0000000000000000000000000000000000000000000000000000000000000007",
@@ -134,9 +264,14 @@ var Secp256k1 = NewWithGLV(
 	[]int64{1, 3, 132, 0, 10},
 	"",
 	secp256k1GLVParams,
-)
+))
+
+func withSecp256k1Backend(c CurveFp) CurveFp {
+	c.Backend = ecmath.NewSecp256k1Backend(c.G, c.N, c.GLVParams)
+	return c
+}
 
-var Prime256v1 = New(
+var Prime256v1 = withP256Backend(New(
 	"prime256v1",
 	"0xffffffff00000001000000000000000000000000fffffffffffffffffffffffc",
 	"0x5ac635d8aa3a93e7b3ebbd55769886bc651d06b0cc53b0f63bce3c3e27d2604b",
@@ -146,41 +281,240 @@ var Prime256v1 = New(
 	"0x4fe342e2fe1a7f9b8ee7eb4a7c0f9e162bce33576b315ececbb6406837bf51f5",
 	[]int64{1, 2, 840, 10045, 3, 1, 7},
 	"P-256",
-)
+))
+
+func withP256Backend(c CurveFp) CurveFp {
+	c.Backend = ecmath.NewP256Backend(c.G, c.N)
+	return c
+}
 
 var P256 = Prime256v1
 
-var supportedCurves = []CurveFp{
-	Secp256k1,
-	Prime256v1,
-}
+var Secp384r1 = New(
+	"secp384r1",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000fffffffc",
+	"0xb3312fa7e23ee7e4988e056be3f82d19181d9c6efe8141120314088f5013875ac656398d8a2ed19d2a85c8edd3ec2aef",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffff0000000000000000ffffffff",
+	"0xffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973",
+	"0xaa87ca22be8b05378eb1c71ef320ad746e1d3b628ba79b9859f741e082542a385502f25dbf55296c3a545e3872760ab7",
+	"0x3617de4a96262c6f5d9e98bf9292dc29f8f41dbd289a147ce9da3113b5f0b8c00a60b1ce1d7e819d7a431d7c90ea0e5f",
+	[]int64{1, 3, 132, 0, 34},
+	"P-384",
+)
 
-// Add registers a new curve so it can be looked up by OID
-func Add(c CurveFp) {
-	supportedCurves = append(supportedCurves, c)
-}
+var P384 = Secp384r1
 
-// GetByOid returns the curve matching the given OID
-func GetByOid(oid []int64) CurveFp {
-	for _, c := range supportedCurves {
-		if IsOidEqual(c.Oid, oid) {
-			return c
-		}
-	}
-	var names []string
-	for _, c := range supportedCurves {
-		names = append(names, c.Name)
-	}
-	panic(fmt.Sprintf("Unknown curve with oid %v; The following are registered: %v",
-		oid,
-		names,
-	))
-}
+var Secp521r1 = New(
+	"secp521r1",
+	"0x01fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc",
+	"0x0051953eb9618e1c9a1f929a21a0b68540eea2da725b99b315f3b8b489918ef109e156193951ec7e937b1652c0bd3bb1bf073573df883d2c34f1ef451fd46b503f00",
+	"0x01ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"0x01fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffa51868783bf2f966b7fcc0148f709a5d03bb5c9b8899c47aebb6fb71e91386409",
+	"0x00c6858e06b70404e9cd9e3ecb662395b4429c648139053fb521f828af606b4d3dbaa14b5e77efe75928fe1dc127a2ffa8de3348b3c1856a429bf97e7e31c2e5bd66",
+	"0x011839296a789a3bc0045c8a5fb42c7d1bd998f54449579b446817afbd17273e662c97ee72995ef42640c550b9013fad0761353c7086a272c24088be94769fd16650",
+	[]int64{1, 3, 132, 0, 35},
+	"P-521",
+)
 
-// CurveByOid is an alias for GetByOid for backward compatibility
-func CurveByOid(oid []int64) CurveFp {
-	return GetByOid(oid)
-}
+var P521 = Secp521r1
+
+// Brainpool curves (RFC 5639). The t1 variants are the isomorphic twists with A = -3.
+var BrainpoolP256r1 = New(
+	"brainpoolP256r1",
+	"0x7d5a0975fc2c3057eef67530417affe7fb8055c126dc5c6ce94a4b44f330b5d9",
+	"0x26dc5c6ce94a4b44f330b5d9bbd77cbf958416295cf7e1ce6bccdc18ff8c07b6",
+	"0xa9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5377",
+	"0xa9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7",
+	"0x8bd2aeb9cb7e57cb2c4b482ffc81b7afb9de27e1e3bd23c23a4453bd9ace3262",
+	"0x547ef835c3dac4fd97f8461a14611dc9c27745132ded8e545c1d54c72f046997",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 7},
+	"",
+)
+
+var BrainpoolP256t1 = New(
+	"brainpoolP256t1",
+	"0xa9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5374",
+	"0x662c61c430d84ea4fe66a7733d0b76b7bf93ebc4af2f49256ae58101fee92b04",
+	"0xa9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5377",
+	"0xa9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7",
+	"0xa3e8eb3cc1cfe7b7732213b23a656149afa142c47aafbc2b79a191562e1305f4",
+	"0x2d996c823439c56d7f7b22e14644417e69bcb6de39d027001dabe8f35b25c9be",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 8},
+	"",
+)
+
+var BrainpoolP384r1 = New(
+	"brainpoolP384r1",
+	"0x7bc382c63d8c150c3c72080ace05afa0c2bea28e4fb22787139165efba91f90f8aa5814a503ad4eb04a8c7dd22ce2826",
+	"0x04a8c7dd22ce28268b39b55416f0447c2fb77de107dcd2a62e880ea53eeb62d57cb4390295dbc9943ab78696fa504c11",
+	"0x8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b412b1da197fb71123acd3a729901d1a71874700133107ec53",
+	"0x8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b31f166e6cac0425a7cf3ab6af6b7fc3103b883202e9046565",
+	"0x1d1c64f068cf45ffa2a63a81b7c13f6b8847a3e77ef14fe3db7fcafe0cbd10e8e826e03436d646aaef87b2e247d4af1e",
+	"0x8abe1d7520f9c2a45cb1eb8e95cfd55262b70b29feec5864e19c054ff99129280e4646217791811142820341263c5315",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 11},
+	"",
+)
+
+var BrainpoolP384t1 = New(
+	"brainpoolP384t1",
+	"0x8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b412b1da197fb71123acd3a729901d1a71874700133107ec50",
+	"0x7f519eada7bda81bd826dba647910f8c4b9346ed8ccdc64e4b1abd11756dce1d2074aa263b88805ced70355a33b471ee",
+	"0x8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b412b1da197fb71123acd3a729901d1a71874700133107ec53",
+	"0x8cb91e82a3386d280f5d6f7e50e641df152f7109ed5456b31f166e6cac0425a7cf3ab6af6b7fc3103b883202e9046565",
+	"0x18de98b02db9a306f2afcd7235f72a819b80ab12ebd653172476fecd462aabffc4ff191b946a5f54d8d0aa2f418808cc",
+	"0x25ab056962d30651a114afd2755ad336747f93475b7a1fca3b88f2b6a208ccfe469408584dc2b2912675bf5b9e582928",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 12},
+	"",
+)
+
+var BrainpoolP512r1 = New(
+	"brainpoolP512r1",
+	"0x7830a3318b603b89e2327145ac234cc594cbdd8d3df91610a83441caea9863bc2ded5d5aa8253aa10a2ef1c98b9ac8b57f1117a72bf2c7b9e7c1ac4d77fc94ca",
+	"0x3df91610a83441caea9863bc2ded5d5aa8253aa10a2ef1c98b9ac8b57f1117a72bf2c7b9e7c1ac4d77fc94cadc083e67984050b75ebae5dd2809bd638016f723",
+	"0xaadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca703308717d4d9b009bc66842aecda12ae6a380e62881ff2f2d82c68528aa6056583a48f3",
+	"0xaadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca70330870553e5c414ca92619418661197fac10471db1d381085ddaddb58796829ca90069",
+	"0x81aee4bdd82ed9645a21322e9c4c6a9385ed9f70b5d916c1b43b62eef4d0098eff3b1f78e2d0d48d50d1687b93b97d5f7c6d5047406a5e688b352209bcb9f822",
+	"0x7dde385d566332ecc0eabfa9cf7822fdf209f70024a57b1aa000c55b881f8111b2dcde494a5f485e5bca4bd88a2763aed1ca2b2fa8f0540678cd1e0f3ad80892",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 13},
+	"",
+)
+
+var BrainpoolP512t1 = New(
+	"brainpoolP512t1",
+	"0xaadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca703308717d4d9b009bc66842aecda12ae6a380e62881ff2f2d82c68528aa6056583a48f0",
+	"0x7cbbbcf9441cfab76e1890e46884eae321f70c0bcb4981527897504bec3e36a62bcdfa2304976540f6450085f2dae145c22553b465763689180ea2571867423e",
+	"0xaadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca703308717d4d9b009bc66842aecda12ae6a380e62881ff2f2d82c68528aa6056583a48f3",
+	"0xaadd9db8dbe9c48b3fd4e6ae33c9fc07cb308db3b3c9d20ed6639cca70330870553e5c414ca92619418661197fac10471db1d381085ddaddb58796829ca90069",
+	"0x640ece5c12788717b9c1ba06cbc2a6feba85842458c56dde9db1758d39c0313d82ba51735cdb3ea499aa77a7d6943a64f7a3f25fe26f06b51baa2696fa9035da",
+	"0x5b534bd595f5af0fa2c892376c84ace1bb4e3019b71634c01131159cae03cee9d9932184beef216bd71df2dadf86a627306ecff96dbb8bace198b61e00f8b332",
+	[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 14},
+	"",
+)
+
+// Sm2p256v1 is the SM2 recommended curve (GB/T 32918.5-2017), used with the sm2 package
+var Sm2p256v1 = New(
+	"sm2p256v1",
+	"0xfffffffeffffffffffffffffffffffffffffffff00000000fffffffffffffffc",
+	"0x28e9fa9e9d9f5e344d5a9e4bcf6509a7f39789f515ab8f92ddbcbd414d940e93",
+	"0xfffffffeffffffffffffffffffffffffffffffff00000000ffffffffffffffff",
+	"0xfffffffeffffffffffffffffffffffff7203df6b21c6052b53bbf40939d54123",
+	"0x32c4ae2c1f1981195f9904466a39c9948fe30bbff2660be1715a4589334c74c7",
+	"0xbc3736a2f4f6779c59bdcee36b692153d0a9877cc62a474002df32e52139f0a0",
+	[]int64{1, 2, 156, 10197, 1, 301},
+	"",
+)
+
+// GOST R 34.10-2012 parameter sets (RFC 7836), used with the gost3410 package.
+// Gost256B, Gost256C and Gost256D are the CryptoPro A, B and C curves of RFC 4357.
+// Gost256A and Gost512C have cofactor 4; N is the order of their prime subgroup.
+var Gost256A = New(
+	"id-tc26-gost-3410-2012-256-paramSetA",
+	"0xc2173f1513981673af4892c23035a27ce25e2013bf95aa33b22c656f277e7335",
+	"0x295f9bae7428ed9ccc20e7c359a9d41a22fccd9108e17bf7ba9337a6f8ae9513",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd97",
+	"0x400000000000000000000000000000000fd8cddfc87b6635c115af556c360c67",
+	"0x91e38443a5e82c0d880923425712b2bb658b9196932e02c78b2582fe742daa28",
+	"0x32879423ab1a0375895786c4bb46e9565fde0b5344766740af268adb32322e5c",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 1, 1},
+	"",
+)
+
+var Gost256B = New(
+	"id-tc26-gost-3410-2012-256-paramSetB",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd94",
+	"0x00000000000000000000000000000000000000000000000000000000000000a6",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd97",
+	"0xffffffffffffffffffffffffffffffff6c611070995ad10045841b09b761b893",
+	"0x0000000000000000000000000000000000000000000000000000000000000001",
+	"0x8d91e471e0989cda27df505a453f2b7635294f2ddf23e3b122acc99c9e9f1e14",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 1, 2},
+	"",
+)
+
+var Gost256C = New(
+	"id-tc26-gost-3410-2012-256-paramSetC",
+	"0x8000000000000000000000000000000000000000000000000000000000000c96",
+	"0x3e1af419a269a5f866a7d3c25c3df80ae979259373ff2b182f49d4ce7e1bbc8b",
+	"0x8000000000000000000000000000000000000000000000000000000000000c99",
+	"0x800000000000000000000000000000015f700cfff1a624e5e497161bcc8a198f",
+	"0x0000000000000000000000000000000000000000000000000000000000000001",
+	"0x3fa8124359f96680b83d1c3eb2c070e5c545c9858d03ecfb744bf8d717717efc",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 1, 3},
+	"",
+)
+
+var Gost256D = New(
+	"id-tc26-gost-3410-2012-256-paramSetD",
+	"0x9b9f605f5a858107ab1ec85e6b41c8aacf846e86789051d37998f7b9022d7598",
+	"0x000000000000000000000000000000000000000000000000000000000000805a",
+	"0x9b9f605f5a858107ab1ec85e6b41c8aacf846e86789051d37998f7b9022d759b",
+	"0x9b9f605f5a858107ab1ec85e6b41c8aa582ca3511eddfb74f02f3a6598980bb9",
+	"0x0000000000000000000000000000000000000000000000000000000000000000",
+	"0x41ece55743711a8c3cbf3783cd08c0ee4d4dc440d4641a8f366e550dfdb3bb67",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 1, 4},
+	"",
+)
+
+var Gost512A = New(
+	"id-tc26-gost-3410-2012-512-paramSetA",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffdc4",
+	"0xe8c2505dedfc86ddc1bd0b2b6667f1da34b82574761cb0e879bd081cfd0b6265ee3cb090f30d27614cb4574010da90dd862ef9d4ebee4761503190785a71c760",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffdc7",
+	"0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff27e69532f48d89116ff22b8d4e0560609b4b38abfad2b85dcacdb1411f10b275",
+	"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000003",
+	"0x7503cfe87a836ae3a61b8816e25450e6ce5e1c93acf1abc1778064fdcbefa921df1626be4fd036e93d75e6a50e3a41e98028fe5fc235f5b889a589cb5215f2a4",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 2, 1},
+	"",
+)
+
+var Gost512B = New(
+	"id-tc26-gost-3410-2012-512-paramSetB",
+	"0x8000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006c",
+	"0x687d1b459dc841457e3e06cf6f5e2517b97c7d614af138bcbf85dc806c4b289f3e965d2db1416d217f8b276fad1ab69c50f78bee1fa3106efb8ccbc7c5140116",
+	"0x8000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000006f",
+	"0x800000000000000000000000000000000000000000000000000000000000000149a1ec142565a545acfdb77bd9d40cfa8b996712101bea0ec6346c54374f25bd",
+	"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002",
+	"0x1a8f7eda389b094c2c071e3647a8940f3c123b697578c213be6dd9e6c8ec7335dcb228fd1edf4a39152cbcaaf8c0398828041055f94ceeec7e21340780fe41bd",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 2, 2},
+	"",
+)
+
+var Gost512C = New(
+	"id-tc26-gost-3410-2012-512-paramSetC",
+	"0xdc9203e514a721875485a529d2c722fb187bc8980eb866644de41c68e143064546e861c0e2c9edd92ade71f46fcf50ff2ad97f951fda9f2a2eb6546f39689bd3",
+	"0xb4c4ee28cebc6c2c8ac12952cf37f16ac7efb6a9f69f4b57ffda2e4f0de5ade038cbc2fff719d2c18de0284b8bfef3b52b8cc7a5f5bf0a3c8d2319a5312557e1",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffdc7",
+	"0x3fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffc98cdba46506ab004c33a9ff5147502cc8eda9e7a769a12694623cef47f023ed",
+	"0xe2e31edfc23de7bdebe241ce593ef5de2295b7a9cbaef021d385f7074cea043aa27272a7ae602bf2a7b9033db9ed3610c6fb85487eae97aac5bc7928c1950148",
+	"0xf5ce40d95b5eb899abbccff5911cb8577939804d6527378b8c108c3d2090ff9be18e2d33e3021ed2ef32d85822423b6304f726aa854bae07d0396e9a9addc40f",
+	[]int64{1, 2, 643, 7, 1, 2, 1, 2, 3},
+	"",
+)
+
+// SEC 2 Koblitz curves over binary fields (a = 0, b = 1), both with cofactor 4
+var Sect233k1 = NewBinary(
+	"sect233k1",
+	"0x00",
+	"0x01",
+	"0x020000000000000000000000000000000000000004000000000000000001",
+	"0x8000000000000000000000000000069d5bb915bcd46efb1ad5f173abdf",
+	"0x017232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126",
+	"0x01db537dece819b7f70f555a67c427a8cd9bf18aeb9b56e0c11056fae6a3",
+	[]int64{1, 3, 132, 0, 26},
+	"K-233",
+)
+
+var Sect283k1 = NewBinary(
+	"sect283k1",
+	"0x00",
+	"0x01",
+	"0x0800000000000000000000000000000000000000000000000000000000000000000010a1",
+	"0x01ffffffffffffffffffffffffffffffffffe9ae2ed07577265dff7f94451e061e163c61",
+	"0x0503213f78ca44883f1a3b8162f188e553cd265f23c1567a16876913b0c2ac2458492836",
+	"0x01ccda380f1c9e318d90f95d07e5426fe87e45c0e8184698e45962364e34116177dd2259",
+	[]int64{1, 3, 132, 0, 16},
+	"K-283",
+)
 
 func IsOidEqual(a, b []int64) bool {
 	if len(a) != len(b) {
@@ -0,0 +1,183 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// EncodeParameters returns the DER hex of the curve's ECParameters (SEC 1 §C.2): its OID,
+// or the explicit SpecifiedECDomain, cofactor included, for curves without one
+func (obj CurveFp) EncodeParameters() string {
+	if len(obj.Oid) > 0 {
+		return utils.EncodePrimitive(utils.Object, obj.Oid)
+	}
+
+	var fieldId string
+	if obj.Binary != nil {
+		terms := new(big.Int).Xor(obj.P, new(big.Int).Lsh(big.NewInt(1), uint(obj.Binary.M)))
+		terms.Xor(terms, big.NewInt(1))
+		var exponents []string
+		for i := terms.BitLen() - 1; i > 0; i-- {
+			if terms.Bit(i) == 1 {
+				exponents = append([]string{utils.EncodePrimitive(utils.Integer, big.NewInt(int64(i)))}, exponents...)
+			}
+		}
+		basis := utils.EncodePrimitive(utils.Object, _trinomialBasisOid) + exponents[0]
+		if len(exponents) == 3 {
+			basis = utils.EncodePrimitive(utils.Object, _pentanomialBasisOid) + utils.EncodeConstructed(exponents...)
+		}
+		fieldId = utils.EncodeConstructed(
+			utils.EncodePrimitive(utils.Object, _characteristicTwoFieldOid),
+			utils.EncodeConstructed(utils.EncodePrimitive(utils.Integer, big.NewInt(int64(obj.Binary.M))), basis),
+		)
+	} else {
+		fieldId = utils.EncodeConstructed(
+			utils.EncodePrimitive(utils.Object, _primeFieldOid),
+			utils.EncodePrimitive(utils.Integer, obj.P),
+		)
+	}
+
+	return utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Integer, big.NewInt(1)),
+		fieldId,
+		utils.EncodeConstructed(
+			utils.EncodePrimitive(utils.OctetString, obj.fieldElementHex(obj.A)),
+			utils.EncodePrimitive(utils.OctetString, obj.fieldElementHex(obj.B)),
+		),
+		utils.EncodePrimitive(utils.OctetString, "04"+obj.fieldElementHex(obj.G.X)+obj.fieldElementHex(obj.G.Y)),
+		utils.EncodePrimitive(utils.Integer, obj.N),
+		utils.EncodePrimitive(utils.Integer, obj.H),
+	)
+}
+
+// FromParameters reads the curve of parsed ECParameters: a named curve OID, or explicit
+// parameters. Explicit parameters of a registered curve return that curve; others are
+// only accepted when they pass Validate, and return a curve without a name or OID.
+func FromParameters(parameters interface{}) CurveFp {
+	switch value := parameters.(type) {
+	case []int64:
+		return GetByOid(value)
+	case []interface{}:
+		return fromSpecifiedDomain(value)
+	}
+	panic(fmt.Sprintf("Curve parameters should be an OID or a SpecifiedECDomain, but %v was found", parameters))
+}
+
+func fromSpecifiedDomain(parsed []interface{}) CurveFp {
+	if len(parsed) < 5 {
+		panic("Explicit curve parameters are missing fields")
+	}
+	fieldId := parsed[1].([]interface{})
+	coefficients := parsed[2].([]interface{})
+	base := parsed[3].(string)
+	N := parsed[4].(*big.Int)
+
+	var field *big.Int
+	binary := false
+	switch fieldType := fieldId[0].([]int64); {
+	case IsOidEqual(fieldType, _primeFieldOid):
+		field = fieldId[1].(*big.Int)
+	case IsOidEqual(fieldType, _characteristicTwoFieldOid):
+		field = binaryPolynomial(fieldId[1].([]interface{}))
+		binary = true
+	default:
+		panic(fmt.Sprintf("Unknown field type %v in explicit curve parameters", fieldType))
+	}
+	if field.BitLen() > _maxExplicitFieldBits {
+		panic(fmt.Sprintf("Explicit curve parameters over fields of more than %v bits aren't supported", _maxExplicitFieldBits))
+	}
+
+	if len(base) < 2 || base[:2] != "04" || len(base)%2 != 0 {
+		panic("Explicit curve parameters should hold an uncompressed base point")
+	}
+	coordinateLength := (len(base) - 2) / 2
+	c, err := parse(
+		_explicitCurveName,
+		"0x0"+coefficients[0].(string),
+		"0x0"+coefficients[1].(string),
+		"0x"+field.Text(16),
+		"0x"+N.Text(16),
+		"0x0"+base[2:2+coordinateLength],
+		"0x0"+base[2+coordinateLength:],
+		nil,
+		"",
+		nil,
+	)
+	if err != nil {
+		panic(err.Error())
+	}
+	if binary {
+		c.Binary = ecmath.NewBinaryField(c.P)
+		c.H = nearestCofactor(c.fieldOrder(), c.N)
+	}
+	if len(parsed) > 5 {
+		if cofactor, ok := parsed[5].(*big.Int); ok {
+			c.H = cofactor
+		}
+	}
+
+	var registered *CurveFp
+	Each(func(known CurveFp) bool {
+		if sameParameters(known, c) {
+			registered = &known
+			return false
+		}
+		return true
+	})
+	if registered != nil {
+		return *registered
+	}
+	if err := c.Validate(); err != nil {
+		panic(fmt.Sprintf("Explicit curve parameters are invalid: %v", err))
+	}
+	return c
+}
+
+// binaryPolynomial reads a Characteristic-two field: m with a trinomial or pentanomial basis
+func binaryPolynomial(parsed []interface{}) *big.Int {
+	m := parsed[0].(*big.Int)
+	if m.Sign() <= 0 || m.Cmp(big.NewInt(_maxExplicitFieldBits)) >= 0 {
+		panic(fmt.Sprintf("Explicit curve parameters over fields of more than %v bits aren't supported", _maxExplicitFieldBits))
+	}
+	basis := parsed[1].([]int64)
+	var exponents []interface{}
+	switch {
+	case IsOidEqual(basis, _trinomialBasisOid):
+		exponents = parsed[2:3]
+	case IsOidEqual(basis, _pentanomialBasisOid):
+		exponents = parsed[2].([]interface{})
+	default:
+		panic(fmt.Sprintf("Unsupported binary field basis %v in explicit curve parameters", basis))
+	}
+
+	polynomial := new(big.Int).SetBit(big.NewInt(1), int(m.Int64()), 1)
+	for _, exponent := range exponents {
+		k := exponent.(*big.Int)
+		if k.Sign() <= 0 || k.Cmp(m) >= 0 {
+			panic(fmt.Sprintf("Binary field basis exponent %v should be between 1 and m - 1", k))
+		}
+		polynomial.SetBit(polynomial, int(k.Int64()), 1)
+	}
+	return polynomial
+}
+
+// fieldElementHex encodes a field element as Length() bytes (SEC 1 §2.3.5)
+func (obj CurveFp) fieldElementHex(value *big.Int) string {
+	return fmt.Sprintf("%0*s", 2*obj.Length(), utils.HexFromInt(value))
+}
+
+const _explicitCurveName = "explicit"
+
+// _maxExplicitFieldBits bounds the work untrusted explicit parameters can cause
+const _maxExplicitFieldBits = 1024
+
+var _primeFieldOid = []int64{1, 2, 840, 10045, 1, 1}
+
+var _characteristicTwoFieldOid = []int64{1, 2, 840, 10045, 1, 2}
+
+var _trinomialBasisOid = []int64{1, 2, 840, 10045, 1, 2, 3, 2}
+
+var _pentanomialBasisOid = []int64{1, 2, 840, 10045, 1, 2, 3, 3}
@@ -0,0 +1,85 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+//
+// Group operations on the points of a curve, in affine coordinates with the point at
+// infinity as Identity. Functions marked constant-time run the same sequence of
+// point operations whatever the secret scalar is, with table lookups by full scans;
+// they're fully constant-time, down to the field arithmetic, on the fixed-limb
+// backends (secp256k1 and P-256), while big.Int arithmetic may still leak operand
+// sizes on the other curves. The others branch on their inputs and are meant for
+// public values.
+//
+
+// Identity returns the point at infinity, the neutral element of the group
+func (obj CurveFp) Identity() point.Point {
+	return point.AtInfinity()
+}
+
+// Add returns p + q. Not constant-time.
+func (obj CurveFp) Add(p point.Point, q point.Point) point.Point {
+	if obj.Binary != nil {
+		return ecmath.BinaryAdd(p, q, obj.A, obj.Binary)
+	}
+	return ecmath.Add(p, q, obj.A, obj.P)
+}
+
+// Neg returns -p: (x, -y) on prime curves and (x, x + y) on binary curves
+func (obj CurveFp) Neg(p point.Point) point.Point {
+	if p.IsAtInfinity() {
+		return obj.Identity()
+	}
+	if obj.Binary != nil {
+		return point.Point{X: new(big.Int).Set(p.X), Y: obj.Binary.Add(p.X, p.Y)}
+	}
+	y := new(big.Int).Mod(p.Y, obj.P)
+	return point.Point{X: new(big.Int).Set(p.X), Y: y.Sub(obj.P, y).Mod(y, obj.P)}
+}
+
+// ScalarMult returns k*p. Constant-time in k.
+func (obj CurveFp) ScalarMult(p point.Point, k *big.Int) point.Point {
+	return obj.Multiply(p, k)
+}
+
+// ScalarBaseMult returns k*G. Constant-time in k.
+func (obj CurveFp) ScalarBaseMult(k *big.Int) point.Point {
+	return obj.MultiplyGenerator(k)
+}
+
+// MultiScalarMult returns scalars[0]*points[0] + ... + scalars[n-1]*points[n-1], or
+// Identity for no points, with ecmath.MultiScalarMult on the big.Int arithmetic and
+// pairs of MultiplyAndAdd on binary curves and the backends, plus a Multiply for an odd
+// last point. Not constant-time.
+func (obj CurveFp) MultiScalarMult(points []point.Point, scalars []*big.Int) point.Point {
+	if len(points) != len(scalars) {
+		panic(fmt.Sprintf("The number of points (%v) and scalars (%v) should match", len(points), len(scalars)))
+	}
+	if obj.Backend == nil && obj.Binary == nil {
+		return ecmath.MultiScalarMult(points, scalars, ecmath.MultiScalarMultParams{A: obj.A, P: obj.P, N: obj.N, GLV: obj.GLVParams})
+	}
+	sum := obj.Identity()
+	for i := 0; i+1 < len(points); i += 2 {
+		sum = obj.Add(sum, obj.MultiplyAndAdd(points[i], scalars[i], points[i+1], scalars[i+1]))
+	}
+	if len(points)%2 == 1 {
+		last := len(points) - 1
+		sum = obj.Add(sum, obj.Multiply(points[last], scalars[last]))
+	}
+	return sum
+}
+
+// EqualPoints returns whether p and q are the same point, comparing affine coordinates
+// (CurveFp.Equal compares curves). Not constant-time.
+func (obj CurveFp) EqualPoints(p point.Point, q point.Point) bool {
+	if p.IsAtInfinity() || q.IsAtInfinity() {
+		return p.IsAtInfinity() && q.IsAtInfinity()
+	}
+	return p.X.Cmp(q.X) == 0 && p.Y.Cmp(q.Y) == 0
+}
@@ -0,0 +1,231 @@
+package curve
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// registry indexes the known curves by name, NIST name, alias and OID. It's safe
+// for concurrent use, so services can register curves while others look them up.
+type registry struct {
+	mutex  sync.RWMutex
+	curves []CurveFp
+	byName map[string]int // names, NIST names and aliases
+	byNist map[string]int
+	byOid  map[string]int
+}
+
+func newRegistry() *registry {
+	return &registry{
+		byName: map[string]int{},
+		byNist: map[string]int{},
+		byOid:  map[string]int{},
+	}
+}
+
+// register adds c under its name, NIST name, OID (if any) and the given aliases. Registering
+// the same curve again only adds the new aliases; an identifier already taken by a
+// different curve is an error, and nothing is registered then.
+func (obj *registry) register(c CurveFp, aliases ...string) error {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	index := len(obj.curves)
+	if len(c.Oid) == 0 {
+		// curves without an OID are only known by their names
+		if existing, ok := obj.byName[c.Name]; ok && sameCurve(obj.curves[existing], c) {
+			index = existing
+		}
+	} else if existing, ok := obj.byOid[oidKey(c.Oid)]; ok {
+		if !sameCurve(obj.curves[existing], c) {
+			return fmt.Errorf("oid %v is already registered for curve %v", c.Oid, obj.curves[existing].Name)
+		}
+		index = existing
+	}
+
+	names := append([]string{c.Name}, aliases...)
+	if c.NistName != "" {
+		names = append(names, c.NistName)
+	}
+	for _, name := range names {
+		if name == "" {
+			return fmt.Errorf("curve %v can't be registered under an empty name", c.Name)
+		}
+		if existing, ok := obj.byName[name]; ok && existing != index {
+			return fmt.Errorf("name %v is already registered for curve %v", name, obj.curves[existing].Name)
+		}
+	}
+
+	if index == len(obj.curves) {
+		obj.curves = append(obj.curves, c)
+		if len(c.Oid) > 0 {
+			obj.byOid[oidKey(c.Oid)] = index
+		}
+	}
+	for _, name := range names {
+		obj.byName[name] = index
+	}
+	if c.NistName != "" {
+		obj.byNist[c.NistName] = index
+	}
+	return nil
+}
+
+func (obj *registry) find(index map[string]int, key string) (CurveFp, bool) {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+
+	i, ok := index[key]
+	if !ok {
+		return CurveFp{}, false
+	}
+	return obj.curves[i], true
+}
+
+func (obj *registry) names() []string {
+	obj.mutex.RLock()
+	defer obj.mutex.RUnlock()
+
+	names := make([]string, len(obj.curves))
+	for i, c := range obj.curves {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// sameCurve tells if a and b have the same name, OID and parameters
+func sameCurve(a CurveFp, b CurveFp) bool {
+	return a.Name == b.Name &&
+		a.NistName == b.NistName &&
+		IsOidEqual(a.Oid, b.Oid) &&
+		sameParameters(a, b)
+}
+
+// sameParameters tells if a and b are the same curve and generator, whatever their names
+func sameParameters(a CurveFp, b CurveFp) bool {
+	return a.A.Cmp(b.A) == 0 &&
+		a.B.Cmp(b.B) == 0 &&
+		a.P.Cmp(b.P) == 0 &&
+		a.N.Cmp(b.N) == 0 &&
+		a.G.X.Cmp(b.G.X) == 0 &&
+		a.G.Y.Cmp(b.G.Y) == 0 &&
+		(a.Binary == nil) == (b.Binary == nil)
+}
+
+// Equal tells if obj and other have the same parameters, e.g. a registered curve and
+// the explicit parameters of a key file
+func (obj CurveFp) Equal(other CurveFp) bool {
+	return sameParameters(obj, other)
+}
+
+func oidKey(oid []int64) string {
+	return strings.Trim(fmt.Sprint(oid), "[]")
+}
+
+var _registry = newRegistry()
+
+func init() {
+	for _, c := range []CurveFp{
+		Secp256k1,
+		Prime256v1,
+		Secp384r1,
+		Secp521r1,
+		BrainpoolP256r1,
+		BrainpoolP256t1,
+		BrainpoolP384r1,
+		BrainpoolP384t1,
+		BrainpoolP512r1,
+		BrainpoolP512t1,
+		Sm2p256v1,
+		Gost256A,
+		Gost256B,
+		Gost256C,
+		Gost256D,
+		Gost512A,
+		Gost512B,
+		Gost512C,
+		Sect233k1,
+		Sect283k1,
+	} {
+		if err := _registry.register(c, _aliases[c.Name]...); err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+// _aliases are the other names the built-in curves go by, e.g. in SEC 2 and OpenSSH
+var _aliases = map[string][]string{
+	"prime256v1": {"secp256r1", "nistp256"},
+	"secp384r1":  {"nistp384"},
+	"secp521r1":  {"nistp521"},
+	"sm2p256v1":  {"SM2"},
+}
+
+// Register adds a curve to the registry under its name, NIST name, OID and any
+// aliases. It's an error to reuse an identifier of a different curve.
+func Register(c CurveFp, aliases ...string) error {
+	return _registry.register(c, aliases...)
+}
+
+// Add registers a new curve so it can be looked up by OID, and panics on conflicts
+func Add(c CurveFp, aliases ...string) {
+	if err := Register(c, aliases...); err != nil {
+		panic(err.Error())
+	}
+}
+
+// ByName returns the curve registered under the given name, NIST name or alias
+func ByName(name string) (CurveFp, error) {
+	if c, ok := _registry.find(_registry.byName, name); ok {
+		return c, nil
+	}
+	return CurveFp{}, fmt.Errorf("unknown curve %v; the following are registered: %v", name, _registry.names())
+}
+
+// ByNistName returns the curve with the given FIPS 186 name, e.g. "P-256"
+func ByNistName(name string) (CurveFp, error) {
+	if c, ok := _registry.find(_registry.byNist, name); ok {
+		return c, nil
+	}
+	return CurveFp{}, fmt.Errorf("unknown NIST curve %v", name)
+}
+
+// ByOid returns the curve with the given OID
+func ByOid(oid []int64) (CurveFp, error) {
+	if c, ok := _registry.find(_registry.byOid, oidKey(oid)); ok {
+		return c, nil
+	}
+	return CurveFp{}, fmt.Errorf("unknown curve with oid %v; the following are registered: %v", oid, _registry.names())
+}
+
+// Each calls f with every registered curve, in registration order, until f returns
+// false. It works on a snapshot, so f may register curves itself.
+func Each(f func(c CurveFp) bool) {
+	_registry.mutex.RLock()
+	curves := append([]CurveFp{}, _registry.curves...)
+	_registry.mutex.RUnlock()
+
+	for _, c := range curves {
+		if !f(c) {
+			return
+		}
+	}
+}
+
+// GetByOid returns the curve matching the given OID, and panics when it's unknown
+func GetByOid(oid []int64) CurveFp {
+	c, err := ByOid(oid)
+	if err != nil {
+		panic(fmt.Sprintf("Unknown curve with oid %v; The following are registered: %v",
+			oid,
+			_registry.names(),
+		))
+	}
+	return c
+}
+
+// CurveByOid is an alias for GetByOid for backward compatibility
+func CurveByOid(oid []int64) CurveFp {
+	return GetByOid(oid)
+}
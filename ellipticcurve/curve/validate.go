@@ -0,0 +1,194 @@
+package curve
+
+import (
+	"fmt"
+	"math/big"
+
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// NewValidated is like NewWithGLV, but returns an error instead of a curve when a
+// parameter can't be parsed or the curve fails Validate
+func NewValidated(name string, AHex string, BHex string, PHex string, NHex string, GxHex string, GyHex string, oid []int64, nistName string, glv ...*ecmath.GLVParams) (CurveFp, error) {
+	var glvParams *ecmath.GLVParams
+	if len(glv) > 0 {
+		glvParams = glv[0]
+	}
+	c, err := parse(name, AHex, BHex, PHex, NHex, GxHex, GyHex, oid, nistName, glvParams)
+	if err != nil {
+		return CurveFp{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return CurveFp{}, err
+	}
+	return c, nil
+}
+
+// Validate checks the domain parameters as in SEC 1 §3.1.1.2.1 and §3.1.2.2.1: a prime
+// (or irreducible) field, a nonsingular curve, G of prime order N, a cofactor within the
+// Hasse bound, no anomalous or MOV weakness and, when set, a GLV basis matching the
+// endomorphism. It returns nil for sound curves.
+func (obj CurveFp) Validate() error {
+	if obj.A == nil || obj.B == nil || obj.P == nil || obj.N == nil || obj.H == nil || obj.G.X == nil || obj.G.Y == nil {
+		return fmt.Errorf("curve %v is missing parameters", obj.Name)
+	}
+
+	if err := obj.validateField(); err != nil {
+		return err
+	}
+	q := obj.fieldOrder()
+	if !obj.isFieldElement(obj.A) || !obj.isFieldElement(obj.B) {
+		return fmt.Errorf("curve %v: A and B should be field elements", obj.Name)
+	}
+	if obj.isSingular() {
+		return fmt.Errorf("curve %v is singular", obj.Name)
+	}
+
+	if !obj.N.ProbablyPrime(_primalityRounds) {
+		return fmt.Errorf("curve %v: N isn't prime", obj.Name)
+	}
+	if obj.G.IsAtInfinity() || !obj.Contains(obj.G) {
+		return fmt.Errorf("curve %v: G isn't on the curve", obj.Name)
+	}
+	if !obj.orderDivides(obj.G, obj.N) {
+		return fmt.Errorf("curve %v: N*G isn't the point at infinity", obj.Name)
+	}
+
+	// with N > 4*sqrt(q), only the cofactor closest to (q + 1)/N may put H*N within the
+	// Hasse bound |q + 1 - H*N| <= 2*sqrt(q)
+	sixteenQ := new(big.Int).Lsh(q, 4)
+	if new(big.Int).Mul(obj.N, obj.N).Cmp(sixteenQ) <= 0 {
+		return fmt.Errorf("curve %v: N should be larger than 4*sqrt(q)", obj.Name)
+	}
+	if h := nearestCofactor(q, obj.N); obj.H.Cmp(h) != 0 {
+		return fmt.Errorf("curve %v: the cofactor should be %v, but H is %v", obj.Name, h, obj.H)
+	}
+	order := new(big.Int).Mul(obj.H, obj.N)
+	trace := new(big.Int).Sub(new(big.Int).Add(q, big.NewInt(1)), order)
+	if new(big.Int).Mul(trace, trace).Cmp(new(big.Int).Lsh(q, 2)) > 0 {
+		return fmt.Errorf("curve %v: no cofactor puts the group order within the Hasse bound", obj.Name)
+	}
+
+	if order.Cmp(q) == 0 {
+		return fmt.Errorf("curve %v is anomalous, since its order is q", obj.Name)
+	}
+	power := big.NewInt(1)
+	for k := 1; k <= _movDegreeBound; k++ {
+		power.Mul(power, q).Mod(power, obj.N)
+		if power.Cmp(big.NewInt(1)) == 0 {
+			return fmt.Errorf("curve %v has embedding degree %v, so it's weak to the MOV attack", obj.Name, k)
+		}
+	}
+
+	if obj.GLVParams != nil {
+		return obj.validateGlv()
+	}
+	return nil
+}
+
+// validateField checks that P is an odd prime, or an irreducible polynomial for binary curves
+func (obj CurveFp) validateField() error {
+	if obj.Binary != nil {
+		if !obj.Binary.Irreducible() {
+			return fmt.Errorf("curve %v: the reduction polynomial isn't irreducible", obj.Name)
+		}
+		return nil
+	}
+	if obj.P.Cmp(big.NewInt(3)) <= 0 || !obj.P.ProbablyPrime(_primalityRounds) {
+		return fmt.Errorf("curve %v: P isn't an odd prime", obj.Name)
+	}
+	return nil
+}
+
+// fieldOrder returns q, the number of field elements: P, or 2^m for binary curves
+func (obj CurveFp) fieldOrder() *big.Int {
+	if obj.Binary != nil {
+		return new(big.Int).Lsh(big.NewInt(1), uint(obj.Binary.M))
+	}
+	return obj.P
+}
+
+// nearestCofactor returns the integer closest to (q + 1)/N, which is the cofactor of any
+// sound curve with N > 4*sqrt(q), or nil without a positive N
+func nearestCofactor(q *big.Int, N *big.Int) *big.Int {
+	if q == nil || N == nil || N.Sign() <= 0 {
+		return nil
+	}
+	h := new(big.Int).Add(q, big.NewInt(1))
+	h.Add(h, new(big.Int).Rsh(N, 1))
+	return h.Div(h, N)
+}
+
+func (obj CurveFp) isFieldElement(value *big.Int) bool {
+	if obj.Binary != nil {
+		return value.Sign() >= 0 && value.BitLen() <= obj.Binary.M
+	}
+	return value.Sign() >= 0 && value.Cmp(obj.P) < 0
+}
+
+// isSingular tells if the discriminant vanishes: 4*A^3 + 27*B^2 = 0 mod P, or B = 0 for
+// binary curves
+func (obj CurveFp) isSingular() bool {
+	if obj.Binary != nil {
+		return obj.B.Sign() == 0
+	}
+	discriminant := new(big.Int).Exp(obj.A, big.NewInt(3), obj.P)
+	discriminant.Mul(discriminant, big.NewInt(4))
+	b2 := new(big.Int).Mul(obj.B, obj.B)
+	discriminant.Add(discriminant, b2.Mul(b2, big.NewInt(27)))
+	return discriminant.Mod(discriminant, obj.P).Sign() == 0
+}
+
+// InSubgroup tells if p is on the curve and in the subgroup of order N generated by G.
+// With a cofactor of 1 every point on the curve is, so only other curves pay for N*p.
+func (obj CurveFp) InSubgroup(p point.Point) bool {
+	if p.IsAtInfinity() || !obj.Contains(p) {
+		return false
+	}
+	if obj.H != nil && obj.H.Cmp(big.NewInt(1)) == 0 {
+		return true
+	}
+	return obj.orderDivides(p, obj.N)
+}
+
+// orderDivides tells if k*p is the point at infinity. The multiplications reduce scalars
+// mod N, so it checks (k - 1)*p = -p instead.
+func (obj CurveFp) orderDivides(p point.Point, k *big.Int) bool {
+	return obj.EqualPoints(obj.Multiply(p, new(big.Int).Sub(k, big.NewInt(1))), obj.Neg(p))
+}
+
+// validateGlv checks that phi(x, y) = (Beta*x, y) acts as multiplication by the lambda
+// both basis vectors (a, b) encode, through a + b*lambda = 0 mod N
+func (obj CurveFp) validateGlv() error {
+	glv := obj.GLVParams
+	if obj.Binary != nil {
+		return fmt.Errorf("curve %v: GLV parameters aren't supported on binary curves", obj.Name)
+	}
+	one := big.NewInt(1)
+	if glv.Beta.Cmp(one) == 0 || new(big.Int).Exp(glv.Beta, big.NewInt(3), obj.P).Cmp(one) != 0 {
+		return fmt.Errorf("curve %v: GLV Beta isn't a nontrivial cube root of unity mod P", obj.Name)
+	}
+	lambdas := make([]*big.Int, 2)
+	for i, vector := range [][2]*big.Int{{glv.A1, glv.B1}, {glv.A2, glv.B2}} {
+		b := new(big.Int).Mod(vector[1], obj.N)
+		if b.Sign() == 0 {
+			return fmt.Errorf("curve %v: GLV basis vector %v has b = 0 mod N", obj.Name, i+1)
+		}
+		lambdas[i] = new(big.Int).Neg(vector[0])
+		lambdas[i].Mul(lambdas[i], ecmath.Inv(b, obj.N)).Mod(lambdas[i], obj.N)
+	}
+	if lambdas[0].Cmp(lambdas[1]) != 0 {
+		return fmt.Errorf("curve %v: the GLV basis vectors encode different lambdas", obj.Name)
+	}
+	phiG := point.Point{X: new(big.Int).Mod(new(big.Int).Mul(glv.Beta, obj.G.X), obj.P), Y: obj.G.Y}
+	if !obj.EqualPoints(obj.Multiply(obj.G, lambdas[0]), phiG) {
+		return fmt.Errorf("curve %v: lambda*G isn't (Beta*Gx, Gy) for the GLV basis", obj.Name)
+	}
+	return nil
+}
+
+// _movDegreeBound is the embedding degree SEC 1 requires to exceed
+const _movDegreeBound = 100
+
+const _primalityRounds = 30
@@ -0,0 +1,45 @@
+//
+// Elliptic Curve Cofactor Diffie-Hellman
+//
+// Z = x(h * d * Q)
+//
+// SEC 1 §3.3.2: https://www.secg.org/sec1-v2.pdf
+//
+
+package ecdh
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+)
+
+// SharedSecret returns the x coordinate of h*d*Q, padded to the curve's byte length. The
+// cofactor h clears any small-order component of the peer's point, so a point outside
+// the subgroup of G can't leak the secret d mod h.
+func SharedSecret(privateKey *privatekey.PrivateKey, publicKey *publickey.PublicKey) []byte {
+	c := privateKey.Curve
+	if !c.Equal(publicKey.Curve) {
+		panic(fmt.Sprintf("The public key curve %v doesn't match the private key curve %v", publicKey.Curve.Name, c.Name))
+	}
+	if publicKey.Point.IsAtInfinity() || !c.Contains(publicKey.Point) {
+		panic(fmt.Sprintf("Point (%v,%v) is not valid for curve %v", publicKey.Point.X, publicKey.Point.Y, c.Name))
+	}
+
+	Q := publicKey.Point
+	if c.H != nil && c.H.Cmp(big.NewInt(1)) > 0 {
+		Q = c.Multiply(Q, c.H)
+		if Q.IsAtInfinity() {
+			panic("The public key point has small order")
+		}
+	}
+	shared := c.Multiply(Q, privateKey.Secret)
+	if shared.IsAtInfinity() {
+		panic("The shared point is at infinity")
+	}
+
+	secret := make([]byte, c.Length())
+	return shared.X.FillBytes(secret)
+}
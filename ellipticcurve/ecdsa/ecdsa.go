@@ -1,9 +1,12 @@
 package ecdsa
 
 import (
+	"fmt"
 	"math/big"
 
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
 	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
@@ -23,30 +26,33 @@ func Sign(message string, privateKey *privatekey.PrivateKey, hashfunc ...utils.H
 	curve := privateKey.Curve
 	numberMessage := utils.NumberFromByteString(byteMessage, curve.NBitLength)
 
+	// a secret outside [1, N - 1] gives a public key outside the subgroup of G
+	if privateKey.Secret.Sign() <= 0 || privateKey.Secret.Cmp(curve.N) >= 0 {
+		panic(fmt.Sprintf("The private key secret should be between 1 and %v.N - 1", curve.Name))
+	}
+
 	zero := big.NewInt(0)
 	r := big.NewInt(0)
 	s := big.NewInt(0)
 	var randSignPoint_X, randSignPoint_Y *big.Int
 
-	genParams := ecmath.MultiplyGeneratorParams{
-		G:          curve.G,
-		A:          curve.A,
-		P:          curve.P,
-		N:          curve.N,
-		NBitLength: curve.NBitLength,
-		Cache:      curve.GeneratorCache,
-	}
+	countermeasures := privateKey.SigningCountermeasures()
 	nextK := utils.Rfc6979(byteMessage, privateKey.Secret, curve.N, curve.NBitLength, hf)
 	for r.Cmp(zero) == 0 || s.Cmp(zero) == 0 {
 		randNum := nextK()
-		randSignPoint := ecmath.MultiplyGenerator(genParams, randNum)
+		randSignPoint := multiplyGenerator(curve, randNum, countermeasures)
 		randSignPoint_X = randSignPoint.X
 		randSignPoint_Y = randSignPoint.Y
 		r = new(big.Int).Mod(randSignPoint.X, curve.N)
 		// s = (numberMessage + r * secret) * inv(randNum, N) mod N
-		s = new(big.Int).Mul(r, privateKey.Secret)
+		secret := privateKey.Secret
+		if countermeasures.BlindedSecret {
+			secret = new(big.Int).Mul(randomScalar(curve), curve.N)
+			secret.Add(secret, privateKey.Secret)
+		}
+		s = new(big.Int).Mul(r, secret)
 		s.Add(s, numberMessage)
-		s.Mul(s, ecmath.Inv(randNum, curve.N))
+		s.Mul(s, inverse(curve, randNum, countermeasures))
 		s.Mod(s, curve.N)
 	}
 
@@ -66,7 +72,65 @@ func Sign(message string, privateKey *privatekey.PrivateKey, hashfunc ...utils.H
 	return signature.New(*r, *s, recoveryId)
 }
 
+// multiplyGenerator computes k*G for Sign with the countermeasures of the key
+func multiplyGenerator(curve curve.CurveFp, k *big.Int, countermeasures privatekey.Countermeasures) point.Point {
+	var z []*big.Int
+	if countermeasures.RandomizedZ {
+		z = append(z, curve.RandomFieldElement())
+	}
+	if countermeasures.ScalarBlinding {
+		// k + m*N runs unreduced, so the bits of k never reach the multiplication
+		bound := new(big.Int).Lsh(big.NewInt(1), ecmath.ScalarBlindingBits)
+		m := utils.Between(big.NewInt(1), bound.Sub(bound, big.NewInt(1)))
+		k = new(big.Int).Add(k, m.Mul(m, curve.N))
+	}
+	return curve.MultiplyGenerator(k, z...)
+}
+
+// inverse computes 1/k mod N, as b/(k*b) for a random b with BlindedInversion
+func inverse(curve curve.CurveFp, k *big.Int, countermeasures privatekey.Countermeasures) *big.Int {
+	if !countermeasures.BlindedInversion {
+		return ecmath.Inv(k, curve.N)
+	}
+	b := randomScalar(curve)
+	blinded := new(big.Int).Mul(k, b)
+	inverse := ecmath.Inv(blinded.Mod(blinded, curve.N), curve.N)
+	return inverse.Mul(inverse, b)
+}
+
+// randomScalar returns a random blinding factor in [1, N - 1]
+func randomScalar(curve curve.CurveFp) *big.Int {
+	return utils.Between(big.NewInt(1), new(big.Int).Sub(curve.N, big.NewInt(1)))
+}
+
 func Verify(message string, sig signature.Signature, publicKey *publickey.PublicKey, hashfunc ...utils.HashFunc) bool {
+	curve := publicKey.Curve
+	// Public key validation: on the curve and, for curves with a cofactor, in the subgroup of G
+	if !curve.InSubgroup(publicKey.Point) {
+		return false
+	}
+
+	// Shamir's trick for verification
+	return verify(message, sig, curve, func(u1 *big.Int, u2 *big.Int) point.Point {
+		return curve.MultiplyAndAdd(curve.G, u1, publicKey.Point, u2)
+	}, hashfunc...)
+}
+
+// VerifyPrepared is Verify for a public key prepared with PublicKey.Precompute, which
+// skips rebuilding the tables of its point for every signature
+func VerifyPrepared(message string, sig signature.Signature, publicKey *publickey.PreparedPublicKey, hashfunc ...utils.HashFunc) bool {
+	if !publicKey.InSubgroup() {
+		return false
+	}
+
+	curve := publicKey.PublicKey().Curve
+	return verify(message, sig, curve, func(u1 *big.Int, u2 *big.Int) point.Point {
+		return curve.MultiplyGeneratorAndAdd(u1, publicKey.Prepared(), u2)
+	}, hashfunc...)
+}
+
+// verify checks sig for a validated public key, with multiplyAndAdd computing u1*G + u2*Q
+func verify(message string, sig signature.Signature, curve curve.CurveFp, multiplyAndAdd func(u1 *big.Int, u2 *big.Int) point.Point, hashfunc ...utils.HashFunc) bool {
 	hf := utils.HashFunc(utils.Sha256)
 	if len(hashfunc) > 0 {
 		hf = hashfunc[0]
@@ -76,7 +140,6 @@ func Verify(message string, sig signature.Signature, publicKey *publickey.Public
 	h.Write([]byte(message))
 	byteMessage := h.Sum(nil)
 
-	curve := publicKey.Curve
 	numberMessage := utils.NumberFromByteString(byteMessage, curve.NBitLength)
 	r := &sig.R
 	s := &sig.S
@@ -90,20 +153,14 @@ func Verify(message string, sig signature.Signature, publicKey *publickey.Public
 		return false
 	}
 
-	// Public key on-curve validation
-	if !curve.Contains(publicKey.Point) {
-		return false
-	}
-
 	inv := ecmath.Inv(s, curve.N)
 
-	// Shamir's trick for verification
 	u1 := new(big.Int).Mul(numberMessage, inv)
 	u1.Mod(u1, curve.N)
 	u2 := new(big.Int).Mul(r, inv)
 	u2.Mod(u2, curve.N)
 
-	v := ecmath.MultiplyAndAddWithGLV(curve.G, u1, publicKey.Point, u2, curve.N, curve.A, curve.P, curve.GLVParams)
+	v := multiplyAndAdd(u1, u2)
 	if v.IsAtInfinity() {
 		return false
 	}
@@ -0,0 +1,117 @@
+//
+// Edwards-curve Digital Signature Algorithm: Ed25519 and Ed25519ph
+//
+// https://datatracker.ietf.org/doc/html/rfc8032
+//
+
+package eddsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/edwards"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Sign creates a pure Ed25519 signature over message, which is deterministic: the same key
+// and message always give the same signature
+func Sign(message string, privateKey *PrivateKey) Signature {
+	return sign([]byte(message), privateKey, nil)
+}
+
+// Verify checks a pure Ed25519 signature over message
+func Verify(message string, sig Signature, publicKey *PublicKey) bool {
+	return verify([]byte(message), sig, publicKey, nil)
+}
+
+// SignPh creates an Ed25519ph signature, which signs the SHA-512 digest of message instead of
+// the message itself. The optional context, up to 255 bytes, must match when verifying.
+func SignPh(message string, privateKey *PrivateKey, context ...string) Signature {
+	return sign(prehash(message), privateKey, dom2(1, context...))
+}
+
+// VerifyPh checks an Ed25519ph signature over message under the given context
+func VerifyPh(message string, sig Signature, publicKey *PublicKey, context ...string) bool {
+	return verify(prehash(message), sig, publicKey, dom2(1, context...))
+}
+
+func sign(message []byte, privateKey *PrivateKey, dom []byte) Signature {
+	c := edwards.Ed25519
+	secret, prefix := privateKey.expand()
+	publicKeyBytes := privateKey.PublicKey().ToBytes()
+
+	// r = SHA-512(dom2(F, C) || prefix || PH(M)) mod L
+	r := hashToScalar(dom, prefix, message)
+	rBytes := c.Encode(c.MultiplyGenerator(r))
+
+	// S = (r + k*s) mod L, where k = SHA-512(dom2(F, C) || R || A || PH(M)) mod L
+	k := hashToScalar(dom, rBytes, publicKeyBytes, message)
+	s := new(big.Int).Mul(k, secret)
+	s.Add(s, r)
+	s.Mod(s, c.N)
+	return Signature{R: rBytes, S: s}
+}
+
+func verify(message []byte, sig Signature, publicKey *PublicKey, dom []byte) bool {
+	c := edwards.Ed25519
+	if sig.S == nil || sig.S.Sign() < 0 || sig.S.Cmp(c.N) >= 0 {
+		return false
+	}
+	r, ok := c.Decode(sig.R)
+	if !ok {
+		return false
+	}
+
+	// S*B = R + k*A
+	k := hashToScalar(dom, sig.R, publicKey.ToBytes(), message)
+	left := c.MultiplyGenerator(sig.S)
+	right := c.Add(r, c.Multiply(publicKey.Point, k))
+	return c.Equal(left, right)
+}
+
+// hashToScalar reads SHA-512 of the concatenated inputs as a little-endian integer mod L
+func hashToScalar(inputs ...[]byte) *big.Int {
+	h := utils.Sha512()
+	for _, input := range inputs {
+		h.Write(input)
+	}
+	return new(big.Int).Mod(littleEndianInt(h.Sum(nil)), edwards.Ed25519.N)
+}
+
+func prehash(message string) []byte {
+	h := utils.Sha512()
+	h.Write([]byte(message))
+	return h.Sum(nil)
+}
+
+// dom2 builds the domain separation prefix of the Ed25519 variants (RFC 8032 §5.1)
+func dom2(phflag byte, context ...string) []byte {
+	ctx := ""
+	if len(context) > 0 {
+		ctx = context[0]
+	}
+	if len(ctx) > 255 {
+		panic(fmt.Sprintf("Ed25519 contexts are limited to 255 bytes, but %v were given", len(ctx)))
+	}
+	dom := append([]byte(_dom2Prefix), phflag, byte(len(ctx)))
+	return append(dom, ctx...)
+}
+
+func littleEndianInt(data []byte) *big.Int {
+	reversed := make([]byte, len(data))
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+	return new(big.Int).SetBytes(reversed)
+}
+
+func littleEndianBytes(value *big.Int, length int) []byte {
+	encoded := value.FillBytes(make([]byte, length))
+	for i, j := 0, length-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return encoded
+}
+
+const _dom2Prefix = "SigEd25519 no Ed25519 collisions"
@@ -0,0 +1,37 @@
+package eddsa
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Signature holds the encoded point R and the scalar S of an Ed25519 signature
+type Signature struct {
+	R []byte
+	S *big.Int
+}
+
+// ToBytes returns the 64-byte RFC 8032 encoding R || S, with S in little-endian
+func (obj Signature) ToBytes() []byte {
+	return append(append([]byte{}, obj.R...), littleEndianBytes(obj.S, _keyLength)...)
+}
+
+func (obj Signature) ToBase64() string {
+	return utils.Base64FromByteString(obj.ToBytes())
+}
+
+func SignatureFromBytes(data []byte) Signature {
+	if len(data) != 2*_keyLength {
+		panic(fmt.Sprintf("Ed25519 signatures should have %v bytes, but %v were given", 2*_keyLength, len(data)))
+	}
+	return Signature{
+		R: append([]byte{}, data[:_keyLength]...),
+		S: littleEndianInt(data[_keyLength:]),
+	}
+}
+
+func SignatureFromBase64(str string) Signature {
+	return SignatureFromBytes(utils.ByteStringFromBase64(str))
+}
@@ -0,0 +1,76 @@
+//
+// Twisted Edwards Curve Equation
+//
+// A*x^2 + y^2 = 1 + D*x^2*y^2 (mod P)
+//
+// https://datatracker.ietf.org/doc/html/rfc8032
+//
+
+package edwards
+
+import (
+	"math/big"
+)
+
+type Curve struct {
+	A     *big.Int
+	D     *big.Int
+	P     *big.Int
+	N     *big.Int
+	H     *big.Int
+	G     Point
+	Name  string
+	Oid   []int64
+	Field Field
+}
+
+func New(name string, AHex string, DHex string, PHex string, NHex string, HHex string, GxHex string, GyHex string, oid []int64) Curve {
+	A, _ := new(big.Int).SetString(AHex, 0)
+	D, _ := new(big.Int).SetString(DHex, 0)
+	P, _ := new(big.Int).SetString(PHex, 0)
+	N, _ := new(big.Int).SetString(NHex, 0)
+	H, _ := new(big.Int).SetString(HHex, 0)
+	Gx, _ := new(big.Int).SetString(GxHex, 0)
+	Gy, _ := new(big.Int).SetString(GyHex, 0)
+
+	field := Field{P: P}
+	return Curve{
+		Name:  name,
+		A:     field.Reduce(A),
+		D:     field.Reduce(D),
+		P:     P,
+		N:     N,
+		H:     H,
+		G:     FromAffine(Gx, Gy, field),
+		Oid:   oid,
+		Field: field,
+	}
+}
+
+// Ed25519 is edwards25519 (RFC 8032 §5.1), birationally equivalent to Curve25519
+var Ed25519 = New(
+	"ed25519",
+	"-0x01",
+	"0x52036cee2b6ffe738cc740797779e89800700a4d4141d8ab75eb4dca135978a3",
+	"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed",
+	"0x1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed",
+	"0x08",
+	"0x216936d3cd6e53fec0a4e231fdd6dc5c692cc7609525a7b2c9562d608f25d51a",
+	"0x6666666666666666666666666666666666666666666666666666666666666658",
+	[]int64{1, 3, 101, 112},
+)
+
+// Contains verifies if the affine point (x, y) is on the curve
+func (obj Curve) Contains(x *big.Int, y *big.Int) bool {
+	f := obj.Field
+	x2 := f.Square(x)
+	y2 := f.Square(y)
+	left := f.Add(f.Mul(obj.A, x2), y2)
+	right := f.Add(big.NewInt(1), f.Mul(obj.D, f.Mul(x2, y2)))
+	return left.Cmp(right) == 0
+}
+
+// Length returns the byte length of encoded points: the bits of P plus the sign bit of x
+func (obj Curve) Length() int {
+	return (obj.P.BitLen() + 8) / 8
+}
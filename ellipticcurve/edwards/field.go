@@ -0,0 +1,72 @@
+package edwards
+
+import (
+	"math/big"
+
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+)
+
+// Field does arithmetic modulo the prime P, e.g. 2^255 - 19 for Ed25519.
+// Every result is a new value reduced to [0, P).
+type Field struct {
+	P *big.Int
+}
+
+func (obj Field) Reduce(a *big.Int) *big.Int {
+	return new(big.Int).Mod(a, obj.P)
+}
+
+func (obj Field) Add(a *big.Int, b *big.Int) *big.Int {
+	return obj.Reduce(new(big.Int).Add(a, b))
+}
+
+func (obj Field) Sub(a *big.Int, b *big.Int) *big.Int {
+	return obj.Reduce(new(big.Int).Sub(a, b))
+}
+
+func (obj Field) Neg(a *big.Int) *big.Int {
+	return obj.Reduce(new(big.Int).Neg(a))
+}
+
+func (obj Field) Mul(a *big.Int, b *big.Int) *big.Int {
+	return obj.Reduce(new(big.Int).Mul(a, b))
+}
+
+func (obj Field) Square(a *big.Int) *big.Int {
+	return obj.Mul(a, a)
+}
+
+// Inv returns a^(P-2), the inverse of a by Fermat's little theorem (0 for a = 0)
+func (obj Field) Inv(a *big.Int) *big.Int {
+	return new(big.Int).Exp(a, new(big.Int).Sub(obj.P, big.NewInt(2)), obj.P)
+}
+
+// Sqrt returns a square root of a, and false when a is not a square
+func (obj Field) Sqrt(a *big.Int) (*big.Int, bool) {
+	a = obj.Reduce(a)
+	if obj.P.Bit(0) == 1 && obj.P.Bit(1) == 0 && obj.P.Bit(2) == 1 {
+		return obj.sqrt5Mod8(a)
+	}
+	halfP := new(big.Int).Rsh(obj.P, 1)
+	if a.Sign() != 0 && new(big.Int).Exp(a, halfP, obj.P).Cmp(big.NewInt(1)) != 0 {
+		return nil, false
+	}
+	return ecmath.ModularSquareRoot(a, obj.P), true
+}
+
+// sqrt5Mod8 computes the root as RFC 8032 §5.1.3 does when P = 5 (mod 8): the candidate
+// a^((P+3)/8) is either a root or needs a factor of sqrt(-1) = 2^((P-1)/4)
+func (obj Field) sqrt5Mod8(a *big.Int) (*big.Int, bool) {
+	exponent := new(big.Int).Add(obj.P, big.NewInt(3))
+	root := new(big.Int).Exp(a, exponent.Rsh(exponent, 3), obj.P)
+	square := obj.Square(root)
+	if square.Cmp(a) == 0 {
+		return root, true
+	}
+	if square.Cmp(obj.Neg(a)) == 0 {
+		exponent = new(big.Int).Sub(obj.P, big.NewInt(1))
+		sqrtMinus1 := new(big.Int).Exp(big.NewInt(2), exponent.Rsh(exponent, 2), obj.P)
+		return obj.Mul(root, sqrtMinus1), true
+	}
+	return nil, false
+}
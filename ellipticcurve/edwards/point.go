@@ -0,0 +1,166 @@
+package edwards
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Point holds extended coordinates (X : Y : Z : T), with x = X/Z, y = Y/Z and x*y = T/Z
+// (Hisil, Wong, Carter and Dawson, "Twisted Edwards Curves Revisited"). The identity is
+// (0 : 1 : 1 : 0); unlike the Weierstrass points there's no separate point at infinity.
+type Point struct {
+	X *big.Int
+	Y *big.Int
+	Z *big.Int
+	T *big.Int
+}
+
+func FromAffine(x *big.Int, y *big.Int, field Field) Point {
+	x = field.Reduce(x)
+	y = field.Reduce(y)
+	return Point{X: x, Y: y, Z: big.NewInt(1), T: field.Mul(x, y)}
+}
+
+func Identity() Point {
+	return Point{X: big.NewInt(0), Y: big.NewInt(1), Z: big.NewInt(1), T: big.NewInt(0)}
+}
+
+func (obj Point) String() string {
+	return fmt.Sprintf("(%s, %s, %s, %s)", obj.X.String(), obj.Y.String(), obj.Z.String(), obj.T.String())
+}
+
+// Affine returns the (x, y) coordinates of p
+func (obj Curve) Affine(p Point) (*big.Int, *big.Int) {
+	f := obj.Field
+	zInv := f.Inv(p.Z)
+	return f.Mul(p.X, zInv), f.Mul(p.Y, zInv)
+}
+
+// Add returns p + q with the unified add-2008-hwcd formulas, which are complete
+// whenever A is a square and D is not, as on edwards25519
+func (obj Curve) Add(p Point, q Point) Point {
+	f := obj.Field
+	a := f.Mul(p.X, q.X)
+	b := f.Mul(p.Y, q.Y)
+	c := f.Mul(f.Mul(p.T, obj.D), q.T)
+	d := f.Mul(p.Z, q.Z)
+	e := f.Sub(f.Sub(f.Mul(f.Add(p.X, p.Y), f.Add(q.X, q.Y)), a), b)
+	ff := f.Sub(d, c)
+	g := f.Add(d, c)
+	h := f.Sub(b, f.Mul(obj.A, a))
+	return Point{X: f.Mul(e, ff), Y: f.Mul(g, h), Z: f.Mul(ff, g), T: f.Mul(e, h)}
+}
+
+// Double returns 2p with the dbl-2008-hwcd formulas, which don't need T
+func (obj Curve) Double(p Point) Point {
+	f := obj.Field
+	a := f.Square(p.X)
+	b := f.Square(p.Y)
+	c := f.Mul(big.NewInt(2), f.Square(p.Z))
+	d := f.Mul(obj.A, a)
+	e := f.Sub(f.Sub(f.Square(f.Add(p.X, p.Y)), a), b)
+	g := f.Add(d, b)
+	ff := f.Sub(g, c)
+	h := f.Sub(d, b)
+	return Point{X: f.Mul(e, ff), Y: f.Mul(g, h), Z: f.Mul(ff, g), T: f.Mul(e, h)}
+}
+
+func (obj Curve) Neg(p Point) Point {
+	f := obj.Field
+	return Point{X: f.Neg(p.X), Y: p.Y, Z: p.Z, T: f.Neg(p.T)}
+}
+
+// Multiply returns k*p with a Montgomery ladder, which runs one addition and one doubling
+// per bit whatever the bit's value. Negative scalars multiply -p.
+func (obj Curve) Multiply(p Point, k *big.Int) Point {
+	if k.Sign() < 0 {
+		return obj.Multiply(obj.Neg(p), new(big.Int).Neg(k))
+	}
+	bits := k.BitLen()
+	if bits < obj.N.BitLen() {
+		bits = obj.N.BitLen()
+	}
+	r0 := Identity()
+	r1 := p
+	for i := bits - 1; i >= 0; i-- {
+		if k.Bit(i) == 0 {
+			r1 = obj.Add(r0, r1)
+			r0 = obj.Double(r0)
+		} else {
+			r0 = obj.Add(r0, r1)
+			r1 = obj.Double(r1)
+		}
+	}
+	return r0
+}
+
+// MultiplyGenerator returns k*G
+func (obj Curve) MultiplyGenerator(k *big.Int) Point {
+	return obj.Multiply(obj.G, k)
+}
+
+// Equal compares p and q projectively, without inverting Z
+func (obj Curve) Equal(p Point, q Point) bool {
+	f := obj.Field
+	return f.Mul(p.X, q.Z).Cmp(f.Mul(q.X, p.Z)) == 0 && f.Mul(p.Y, q.Z).Cmp(f.Mul(q.Y, p.Z)) == 0
+}
+
+func (obj Curve) IsIdentity(p Point) bool {
+	return obj.Equal(p, Identity())
+}
+
+// Encode returns the RFC 8032 encoding of p: y in little-endian, with the sign (lowest bit)
+// of x in the most significant bit of the last byte
+func (obj Curve) Encode(p Point) []byte {
+	x, y := obj.Affine(p)
+	length := obj.Length()
+	encoded := make([]byte, length)
+	y.FillBytes(encoded)
+	reverse(encoded)
+	encoded[length-1] |= byte(x.Bit(0) << 7)
+	return encoded
+}
+
+// Decode reads an encoded point, reporting false when the bytes aren't a canonical
+// encoding of a point on the curve (RFC 8032 §5.1.3)
+func (obj Curve) Decode(data []byte) (Point, bool) {
+	length := obj.Length()
+	if len(data) != length {
+		return Point{}, false
+	}
+	f := obj.Field
+	encoded := make([]byte, length)
+	copy(encoded, data)
+	xSign := uint(encoded[length-1] >> 7)
+	encoded[length-1] &= 0x7f
+	reverse(encoded)
+	y := new(big.Int).SetBytes(encoded)
+	if y.Cmp(obj.P) >= 0 {
+		return Point{}, false
+	}
+
+	// x^2 = (y^2 - 1) / (D*y^2 - A)
+	y2 := f.Square(y)
+	u := f.Sub(y2, big.NewInt(1))
+	v := f.Sub(f.Mul(obj.D, y2), obj.A)
+	if v.Sign() == 0 {
+		return Point{}, false
+	}
+	x, ok := f.Sqrt(f.Mul(u, f.Inv(v)))
+	if !ok {
+		return Point{}, false
+	}
+	if x.Sign() == 0 && xSign == 1 {
+		return Point{}, false
+	}
+	if x.Bit(0) != xSign {
+		x = f.Neg(x)
+	}
+	return FromAffine(x, y, f), true
+}
+
+func reverse(data []byte) {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+}
@@ -0,0 +1,150 @@
+//
+// GOST R 34.10-2012 digital signatures with the Streebog (GOST R 34.11-2012) hash
+//
+// https://datatracker.ietf.org/doc/html/rfc7091
+//
+// Keys are written with the GOST R 34.10-2012 key algorithms of RFC 9215 by the functions
+// of keys.go. The ToDer and ToPem methods of privatekey and publickey use id-ecPublicKey,
+// which GOST tooling such as the OpenSSL GOST engine doesn't read.
+//
+// https://datatracker.ietf.org/doc/html/rfc9215
+//
+
+package gost3410
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Sign creates a GOST R 34.10-2012 signature over message. The hash defaults to Streebog-256,
+// or Streebog-512 on curves over 256 bits, as the standard pairs them.
+func Sign(message string, privateKey *privatekey.PrivateKey, hashfunc ...utils.HashFunc) signature.Signature {
+	hf := hashFor(privateKey.Curve, hashfunc...)
+	h := hf()
+	h.Write([]byte(message))
+	return signHash(h.Sum(nil), privateKey, hf)
+}
+
+// Verify checks a GOST R 34.10-2012 signature over message, hashed as in Sign
+func Verify(message string, sig signature.Signature, publicKey *publickey.PublicKey, hashfunc ...utils.HashFunc) bool {
+	h := hashFor(publicKey.Curve, hashfunc...)()
+	h.Write([]byte(message))
+	return VerifyHash(h.Sum(nil), sig, publicKey)
+}
+
+// SignHash signs an already computed digest, in the byte order the hash outputs it
+func SignHash(digest []byte, privateKey *privatekey.PrivateKey) signature.Signature {
+	return signHash(digest, privateKey, hashFor(privateKey.Curve))
+}
+
+func signHash(digest []byte, privateKey *privatekey.PrivateKey, hf utils.HashFunc) signature.Signature {
+	c := privateKey.Curve
+	if privateKey.Secret.Sign() <= 0 || privateKey.Secret.Cmp(c.N) >= 0 {
+		panic(fmt.Sprintf("The private key secret should be between 1 and %v.N - 1", c.Name))
+	}
+	e := digestNumber(digest, c)
+
+	nextK := utils.Rfc6979(digest, privateKey.Secret, c.N, c.NBitLength, hf)
+	for {
+		k := nextK()
+		randSignPoint := c.MultiplyGenerator(k)
+		r := new(big.Int).Mod(randSignPoint.X, c.N)
+		if r.Sign() == 0 {
+			continue
+		}
+		// s = (r*d + k*e) mod q
+		s := new(big.Int).Mul(r, privateKey.Secret)
+		s.Add(s, new(big.Int).Mul(k, e))
+		s.Mod(s, c.N)
+		if s.Sign() == 0 {
+			continue
+		}
+		return signature.New(*r, *s)
+	}
+}
+
+// VerifyHash checks a signature over an already computed digest
+func VerifyHash(digest []byte, sig signature.Signature, publicKey *publickey.PublicKey) bool {
+	c := publicKey.Curve
+	r := &sig.R
+	s := &sig.S
+
+	one := big.NewInt(1)
+	nMinus1 := new(big.Int).Sub(c.N, one)
+	if r.Cmp(one) < 0 || r.Cmp(nMinus1) > 0 {
+		return false
+	}
+	if s.Cmp(one) < 0 || s.Cmp(nMinus1) > 0 {
+		return false
+	}
+	if !c.InSubgroup(publicKey.Point) {
+		return false
+	}
+
+	// C = z1*G + z2*Q, where v = e^-1, z1 = s*v and z2 = -r*v
+	v := ecmath.Inv(digestNumber(digest, c), c.N)
+	z1 := new(big.Int).Mul(s, v)
+	z1.Mod(z1, c.N)
+	z2 := new(big.Int).Mul(r, v)
+	z2.Neg(z2)
+	z2.Mod(z2, c.N)
+
+	point := c.MultiplyAndAdd(c.G, z1, publicKey.Point, z2)
+	if point.IsAtInfinity() {
+		return false
+	}
+	return new(big.Int).Mod(point.X, c.N).Cmp(r) == 0
+}
+
+// ToBytes encodes a signature as s || r, each as long as the curve order, which is the
+// layout RFC 4491 and RFC 7091 use in certificates and CMS
+func ToBytes(sig signature.Signature, c curve.CurveFp) []byte {
+	length := (c.N.BitLen() + 7) / 8
+	encoded := make([]byte, 2*length)
+	sig.S.FillBytes(encoded[:length])
+	sig.R.FillBytes(encoded[length:])
+	return encoded
+}
+
+// FromBytes decodes an s || r signature
+func FromBytes(data []byte) signature.Signature {
+	if len(data) == 0 || len(data)%2 != 0 {
+		panic(fmt.Sprintf("GOST signatures must have an even, non-zero length, but %v bytes were given", len(data)))
+	}
+	length := len(data) / 2
+	s := new(big.Int).SetBytes(data[:length])
+	r := new(big.Int).SetBytes(data[length:])
+	return signature.New(*r, *s)
+}
+
+func hashFor(c curve.CurveFp, hashfunc ...utils.HashFunc) utils.HashFunc {
+	if len(hashfunc) > 0 {
+		return hashfunc[0]
+	}
+	if c.Length() > 32 {
+		return utils.Streebog512
+	}
+	return utils.Streebog256
+}
+
+// digestNumber reads the digest as a little-endian integer e mod q, replacing 0 with 1
+func digestNumber(digest []byte, c curve.CurveFp) *big.Int {
+	reversed := make([]byte, len(digest))
+	for i, b := range digest {
+		reversed[len(digest)-1-i] = b
+	}
+	e := new(big.Int).SetBytes(reversed)
+	e.Mod(e, c.N)
+	if e.Sign() == 0 {
+		e.SetInt64(1)
+	}
+	return e
+}
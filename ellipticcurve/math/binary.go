@@ -0,0 +1,400 @@
+package math
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// BinaryField describes GF(2^m) in polynomial basis: elements are polynomials over GF(2)
+// of degree below m, stored as the bits of a big.Int, and products are reduced by the
+// irreducible trinomial or pentanomial Poly = x^m + ... + 1. The arithmetic runs on
+// 64-bit words internally.
+type BinaryField struct {
+	M     int
+	Poly  *big.Int
+	terms []int // exponents of Poly below m, for the word-wise reduction
+	words int
+}
+
+// NewBinaryField prepares the field reduced by poly. The word-wise reduction needs the
+// second highest term of poly to sit at least 64 bits below x^m, as in all SEC 2 curves.
+func NewBinaryField(poly *big.Int) *BinaryField {
+	m := poly.BitLen() - 1
+	var terms []int
+	for i := m - 1; i >= 0; i-- {
+		if poly.Bit(i) == 1 {
+			terms = append(terms, i)
+		}
+	}
+	if m < 64 || len(terms) == 0 || terms[0] > m-64 {
+		panic(fmt.Sprintf("Reduction polynomial %x is not supported for binary curves", poly))
+	}
+	return &BinaryField{M: m, Poly: poly, terms: terms, words: (m + 63) / 64}
+}
+
+// gf2m is a field element as little-endian 64-bit words
+type gf2m []uint64
+
+func (obj *BinaryField) fromBig(a *big.Int) gf2m {
+	element := make(gf2m, obj.words)
+	bytes := a.FillBytes(make([]byte, 8*obj.words))
+	for i := range element {
+		for j := 0; j < 8; j++ {
+			element[i] |= uint64(bytes[len(bytes)-1-8*i-j]) << (8 * j)
+		}
+	}
+	return element
+}
+
+func (obj *BinaryField) toBig(a gf2m) *big.Int {
+	bytes := make([]byte, 8*obj.words)
+	for i, word := range a {
+		for j := 0; j < 8; j++ {
+			bytes[len(bytes)-1-8*i-j] = byte(word >> (8 * j))
+		}
+	}
+	return new(big.Int).SetBytes(bytes)
+}
+
+func (obj *BinaryField) one() gf2m {
+	element := make(gf2m, obj.words)
+	element[0] = 1
+	return element
+}
+
+func (obj *BinaryField) add(a gf2m, b gf2m) gf2m {
+	sum := make(gf2m, obj.words)
+	for i := range sum {
+		sum[i] = a[i] ^ b[i]
+	}
+	return sum
+}
+
+func (obj *BinaryField) mul(a gf2m, b gf2m) gf2m {
+	product := make([]uint64, 2*obj.words)
+	for i, x := range a {
+		for j, y := range b {
+			hi, lo := carrylessMul(x, y)
+			product[i+j] ^= lo
+			product[i+j+1] ^= hi
+		}
+	}
+	return obj.reduce(product)
+}
+
+// square spreads the bits of a apart, since squaring a polynomial over GF(2) just
+// doubles every exponent
+func (obj *BinaryField) square(a gf2m) gf2m {
+	product := make([]uint64, 2*obj.words)
+	for i, word := range a {
+		product[2*i] = spreadBits(uint32(word))
+		product[2*i+1] = spreadBits(uint32(word >> 32))
+	}
+	return obj.reduce(product)
+}
+
+// reduce folds the bits at x^m and above back with x^m = (Poly - x^m), a word at a time
+func (obj *BinaryField) reduce(c []uint64) gf2m {
+	m := obj.M
+	top := m / 64
+	for j := len(c) - 1; j > top; j-- {
+		word := c[j]
+		c[j] = 0
+		for _, term := range obj.terms {
+			xorAt(c, word, 64*j-m+term)
+		}
+	}
+	shift := uint(m % 64)
+	word := c[top] >> shift
+	c[top] &= (uint64(1) << shift) - 1
+	for _, term := range obj.terms {
+		xorAt(c, word, term)
+	}
+	return gf2m(c[:obj.words])
+}
+
+// inv returns a^(2^m - 2) = a^-1, as the product of a^2, a^4, ..., a^(2^(m-1))
+func (obj *BinaryField) inv(a gf2m) gf2m {
+	result := obj.one()
+	power := a
+	for i := 1; i < obj.M; i++ {
+		power = obj.square(power)
+		result = obj.mul(result, power)
+	}
+	return result
+}
+
+func (obj *BinaryField) isZero(a gf2m) bool {
+	var bits uint64
+	for _, word := range a {
+		bits |= word
+	}
+	return bits == 0
+}
+
+func (obj *BinaryField) equal(a gf2m, b gf2m) bool {
+	return obj.isZero(obj.add(a, b))
+}
+
+// cswap swaps a and b when swap is 1 and leaves them when it's 0, without branching
+func (obj *BinaryField) cswap(a gf2m, b gf2m, swap uint64) {
+	mask := -swap
+	for i := range a {
+		t := mask & (a[i] ^ b[i])
+		a[i] ^= t
+		b[i] ^= t
+	}
+}
+
+// Add returns a + b, i.e. a XOR b
+func (obj *BinaryField) Add(a *big.Int, b *big.Int) *big.Int {
+	return new(big.Int).Xor(a, b)
+}
+
+func (obj *BinaryField) Mul(a *big.Int, b *big.Int) *big.Int {
+	return obj.toBig(obj.mul(obj.fromBig(a), obj.fromBig(b)))
+}
+
+func (obj *BinaryField) Inv(a *big.Int) *big.Int {
+	return obj.toBig(obj.inv(obj.fromBig(a)))
+}
+
+// Sqrt returns a^(2^(m-1)), the unique square root in GF(2^m)
+func (obj *BinaryField) Sqrt(a *big.Int) *big.Int {
+	root := obj.fromBig(a)
+	for i := 1; i < obj.M; i++ {
+		root = obj.square(root)
+	}
+	return obj.toBig(root)
+}
+
+// HalfTrace returns the sum of a^(2^(2i)) for i = 0..(m-1)/2, which solves z^2 + z = a
+// whenever a has trace 0 and m is odd
+func (obj *BinaryField) HalfTrace(a *big.Int) *big.Int {
+	element := obj.fromBig(a)
+	result := element
+	for i := 0; i < (obj.M-1)/2; i++ {
+		element = obj.square(obj.square(element))
+		result = obj.add(result, element)
+	}
+	return obj.toBig(result)
+}
+
+// Irreducible runs Rabin's test on Poly: x^(2^m) = x, and x^(2^(m/d)) - x shares no
+// factor with Poly for each prime d dividing m
+func (obj *BinaryField) Irreducible() bool {
+	x := obj.fromBig(big.NewInt(2))
+	frobenius := func(k int) gf2m {
+		power := x
+		for i := 0; i < k; i++ {
+			power = obj.square(power)
+		}
+		return power
+	}
+	if !obj.equal(frobenius(obj.M), x) {
+		return false
+	}
+	for d := 2; d <= obj.M; d++ {
+		if obj.M%d != 0 || !isSmallPrime(d) {
+			continue
+		}
+		if polynomialGcd(obj.toBig(obj.add(frobenius(obj.M/d), x)), obj.Poly).Cmp(big.NewInt(1)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Contains verifies if p is on y^2 + x*y = x^3 + A*x^2 + B
+func (obj *BinaryField) Contains(p point.Point, A *big.Int, B *big.Int) bool {
+	if p.X.Sign() < 0 || p.Y.Sign() < 0 || p.X.BitLen() > obj.M || p.Y.BitLen() > obj.M {
+		return false
+	}
+	x, y := obj.fromBig(p.X), obj.fromBig(p.Y)
+	x2 := obj.square(x)
+	left := obj.add(obj.square(y), obj.mul(x, y))
+	right := obj.add(obj.add(obj.mul(x2, x), obj.mul(obj.fromBig(A), x2)), obj.fromBig(B))
+	return obj.equal(left, right)
+}
+
+// BinaryMultiply computes n*p on the binary curve y^2 + x*y = x^3 + A*x^2 + B with the
+// Lopez-Dahab x-only Montgomery ladder, which runs the same operations whatever the bits
+// of n, and recovers y at the end. A nonzero z randomizes the projective coordinates.
+func BinaryMultiply(p point.Point, n *big.Int, N *big.Int, A *big.Int, B *big.Int, field *BinaryField, z ...*big.Int) point.Point {
+	if n.Sign() < 0 || n.Cmp(N) >= 0 {
+		n = new(big.Int).Mod(n, N)
+	}
+	return binaryLadder(p, n, N.BitLen(), B, field, z...)
+}
+
+// BinaryMultiplyGenerator is BinaryMultiply for a generator G of order N, which also
+// takes a blinded scalar k + m*N, with 0 < m < 2^ScalarBlindingBits, unreduced, over
+// ScalarBlindingBits more steps of the ladder
+func BinaryMultiplyGenerator(G point.Point, n *big.Int, N *big.Int, A *big.Int, B *big.Int, field *BinaryField, z ...*big.Int) point.Point {
+	if n.Sign() < 0 || n.BitLen() > N.BitLen()+ScalarBlindingBits {
+		n = new(big.Int).Mod(n, N)
+	}
+	length := N.BitLen()
+	if n.Cmp(N) >= 0 {
+		length += ScalarBlindingBits
+	}
+	return binaryLadder(G, n, length, B, field, z...)
+}
+
+// binaryLadder computes n*p, for 0 <= n < 2^length, in length steps
+func binaryLadder(p point.Point, n *big.Int, length int, B *big.Int, field *BinaryField, z ...*big.Int) point.Point {
+	if p.IsAtInfinity() || n.Sign() == 0 {
+		return point.AtInfinity()
+	}
+	x, y := field.fromBig(p.X), field.fromBig(p.Y)
+	if field.isZero(x) {
+		// (0, sqrt(B)) is its own negative, so it has order 2
+		if n.Bit(0) == 0 {
+			return point.AtInfinity()
+		}
+		return p
+	}
+	b := field.fromBig(B)
+
+	// (x1 : z1) = j*p and (x2 : z2) = (j+1)*p, starting from j = 0 and (x2 : z2) =
+	// (z*x : z); x2 is a new element, since the swaps work in place and x is needed
+	// until the end
+	lift := field.one()
+	if len(z) > 0 {
+		if z[0].Sign() <= 0 || z[0].BitLen() > field.M {
+			panic(fmt.Sprintf("The projective Z should be a nonzero element of GF(2^%v)", field.M))
+		}
+		lift = field.fromBig(z[0])
+	}
+	x1, z1 := field.one(), make(gf2m, field.words)
+	x2, z2 := field.mul(x, lift), lift
+	for i := length - 1; i >= 0; i-- {
+		bit := uint64(n.Bit(i))
+		field.cswap(x1, x2, bit)
+		field.cswap(z1, z2, bit)
+		// (x2 : z2) = (x1 : z1) + (x2 : z2), whose difference is always p
+		t1 := field.mul(x1, z2)
+		t2 := field.mul(x2, z1)
+		z2 = field.square(field.add(t1, t2))
+		x2 = field.add(field.mul(x, z2), field.mul(t1, t2))
+		// (x1 : z1) = 2 * (x1 : z1)
+		xx := field.square(x1)
+		zz := field.square(z1)
+		x1 = field.add(field.square(xx), field.mul(b, field.square(zz)))
+		z1 = field.mul(xx, zz)
+		field.cswap(x1, x2, bit)
+		field.cswap(z1, z2, bit)
+	}
+
+	if field.isZero(z1) {
+		return point.AtInfinity()
+	}
+	if field.isZero(z2) {
+		// n*p + p is the point at infinity, so n*p = -p = (x, x + y)
+		return point.Point{X: new(big.Int).Set(p.X), Y: field.toBig(field.add(x, y))}
+	}
+
+	// y1 = (x + x1/z1) * ((x1 + x*z1) * (x2 + x*z2) + (x^2 + y) * z1*z2) / (x*z1*z2) + y
+	z1z2 := field.mul(z1, z2)
+	w := field.inv(field.mul(x, z1z2))
+	affineX := field.mul(field.mul(x1, field.mul(x, z2)), w)
+	t := field.mul(field.add(x1, field.mul(x, z1)), field.add(x2, field.mul(x, z2)))
+	t = field.add(t, field.mul(field.add(field.square(x), y), z1z2))
+	affineY := field.add(field.mul(field.mul(field.add(x, affineX), t), w), y)
+	return point.Point{X: field.toBig(affineX), Y: field.toBig(affineY)}
+}
+
+// BinaryAdd adds two affine points on the binary curve y^2 + x*y = x^3 + A*x^2 + B
+func BinaryAdd(p point.Point, q point.Point, A *big.Int, field *BinaryField) point.Point {
+	if p.IsAtInfinity() {
+		return q
+	}
+	if q.IsAtInfinity() {
+		return p
+	}
+	x1, y1 := field.fromBig(p.X), field.fromBig(p.Y)
+	x2, y2 := field.fromBig(q.X), field.fromBig(q.Y)
+
+	var lambda gf2m
+	if field.equal(x1, x2) {
+		// q = -p = (x1, x1 + y1) sums to infinity, and so does doubling a point with x = 0
+		if !field.equal(y2, y1) || field.isZero(x1) {
+			return point.AtInfinity()
+		}
+		lambda = field.add(x1, field.mul(y1, field.inv(x1)))
+		x3 := field.add(field.add(field.square(lambda), lambda), field.fromBig(A))
+		y3 := field.add(field.square(x1), field.mul(field.add(lambda, field.one()), x3))
+		return point.Point{X: field.toBig(x3), Y: field.toBig(y3)}
+	}
+
+	lambda = field.mul(field.add(y1, y2), field.inv(field.add(x1, x2)))
+	x3 := field.add(field.add(field.add(field.square(lambda), lambda), field.add(x1, x2)), field.fromBig(A))
+	y3 := field.add(field.add(field.mul(lambda, field.add(x1, x3)), x3), y1)
+	return point.Point{X: field.toBig(x3), Y: field.toBig(y3)}
+}
+
+// BinaryMultiplyAndAdd computes n1*p1 + n2*p2 on a binary curve
+func BinaryMultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int, N *big.Int, A *big.Int, B *big.Int, field *BinaryField) point.Point {
+	return BinaryAdd(
+		BinaryMultiply(p1, n1, N, A, B, field),
+		BinaryMultiply(p2, n2, N, A, B, field),
+		A,
+		field,
+	)
+}
+
+// carrylessMul multiplies two 64-bit polynomials over GF(2) into a 128-bit (hi, lo) pair
+func carrylessMul(a uint64, b uint64) (uint64, uint64) {
+	var hi, lo uint64
+	for i := uint(0); i < 64; i++ {
+		mask := -((b >> i) & 1)
+		lo ^= (a << i) & mask
+		if i > 0 {
+			hi ^= (a >> (64 - i)) & mask
+		}
+	}
+	return hi, lo
+}
+
+// spreadBits moves bit i of x to bit 2i
+func spreadBits(x uint32) uint64 {
+	spread := uint64(x)
+	spread = (spread | spread<<16) & 0x0000ffff0000ffff
+	spread = (spread | spread<<8) & 0x00ff00ff00ff00ff
+	spread = (spread | spread<<4) & 0x0f0f0f0f0f0f0f0f
+	spread = (spread | spread<<2) & 0x3333333333333333
+	spread = (spread | spread<<1) & 0x5555555555555555
+	return spread
+}
+
+// xorAt adds word, shifted to start at bit position, into c
+func xorAt(c []uint64, word uint64, position int) {
+	index, shift := position/64, uint(position%64)
+	c[index] ^= word << shift
+	if shift > 0 && index+1 < len(c) {
+		c[index+1] ^= word >> (64 - shift)
+	}
+}
+
+// polynomialGcd returns the greatest common divisor of two polynomials over GF(2)
+func polynomialGcd(a *big.Int, b *big.Int) *big.Int {
+	a, b = new(big.Int).Set(a), new(big.Int).Set(b)
+	for b.Sign() != 0 {
+		for a.BitLen() >= b.BitLen() {
+			a.Xor(a, new(big.Int).Lsh(b, uint(a.BitLen()-b.BitLen())))
+		}
+		a, b = b, a
+	}
+	return a
+}
+
+func isSmallPrime(n int) bool {
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return n > 1
+}
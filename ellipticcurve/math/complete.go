@@ -0,0 +1,279 @@
+package math
+
+import (
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// projective is a point in homogeneous projective coordinates, (X/Z, Y/Z) in affine
+// ones, with the point at infinity as (0 : 1 : 0)
+type projective struct {
+	X *big.Int
+	Y *big.Int
+	Z *big.Int
+}
+
+// completeCurve evaluates the complete addition and doubling formulas of Renes,
+// Costello and Batina ("Complete addition formulas for prime order elliptic curves",
+// 2016, algorithms 1, 3, 4, 6, 7 and 9), with the A = 0 and A = -3 variants picked by
+// curveMode. They have no special cases: P + Q, P + P, P + (-P) and sums with the point
+// at infinity all run the same operations. They are exceptional only when P - Q has
+// order 2, which never happens between multiples of a point of odd order.
+type completeCurve struct {
+	mode curveMode
+	b    *big.Int
+	b3   *big.Int // 3*b
+
+	quotient *big.Int // scratch space of mul
+}
+
+// newCompleteCurve takes B from an affine point of the curve, on the isomorphic A = -3
+// curve when the mode uses one, since the callers only get A and P. Like the Jacobian
+// formulas, which don't use B, the arithmetic then follows the curve the point is on.
+func newCompleteCurve(p point.Point, mode curveMode) completeCurve {
+	P := mode.P
+	// b = y^2 - x^3 - A*x
+	b := new(big.Int).Mul(p.Y, p.Y)
+	x3 := new(big.Int).Mul(p.X, p.X)
+	x3.Add(x3, mode.A).Mul(x3, p.X)
+	b.Sub(b, x3).Mod(b, P)
+	b3 := new(big.Int).Mul(b, big.NewInt(3))
+	return completeCurve{mode: mode, b: b, b3: b3.Mod(b3, P), quotient: new(big.Int)}
+}
+
+func projectiveAtInfinity() projective {
+	return projective{X: big.NewInt(0), Y: big.NewInt(1), Z: big.NewInt(0)}
+}
+
+// toPoint converts the point back to affine coordinates on the original curve
+func (obj completeCurve) toPoint(p projective) point.Point {
+	P := obj.mode.P
+	if new(big.Int).Mod(p.Z, P).Sign() == 0 {
+		return point.AtInfinity()
+	}
+	zInv := Inv(p.Z, P)
+	x := new(big.Int).Mul(p.X, zInv)
+	y := new(big.Int).Mul(p.Y, zInv)
+	affine := point.Point{X: x.Mod(x, P), Y: y.Mod(y, P)}
+	if obj.mode.iso != nil {
+		return obj.mode.iso.backward(affine, P)
+	}
+	return affine
+}
+
+// mul reduces its product into (-P, P), while add and sub leave their results
+// unreduced: they only feed products, and the formulas reduce their outputs with
+// reduce
+func (obj completeCurve) mul(a *big.Int, b *big.Int) *big.Int {
+	z := new(big.Int).Mul(a, b)
+	obj.quotient.QuoRem(z, obj.mode.P, z)
+	return z
+}
+
+func (obj completeCurve) add(a *big.Int, b *big.Int) *big.Int {
+	return new(big.Int).Add(a, b)
+}
+
+func (obj completeCurve) sub(a *big.Int, b *big.Int) *big.Int {
+	return new(big.Int).Sub(a, b)
+}
+
+func (obj completeCurve) reduce(x *big.Int, y *big.Int, z *big.Int) projective {
+	P := obj.mode.P
+	return projective{X: x.Mod(x, P), Y: y.Mod(y, P), Z: z.Mod(z, P)}
+}
+
+// Add returns p + q for any two points of the curve
+func (obj completeCurve) Add(p projective, q projective) projective {
+	switch {
+	case obj.mode.aIsZero:
+		return obj.addAZero(p, q)
+	case obj.mode.aIsMin3:
+		return obj.addAMinus3(p, q)
+	}
+	return obj.addGeneric(p, q)
+}
+
+// Double returns 2*p for any point of the curve
+func (obj completeCurve) Double(p projective) projective {
+	switch {
+	case obj.mode.aIsZero:
+		return obj.doubleAZero(p)
+	case obj.mode.aIsMin3:
+		return obj.doubleAMinus3(p)
+	}
+	return obj.doubleGeneric(p)
+}
+
+// addGeneric is algorithm 1, for any A
+func (obj completeCurve) addGeneric(p projective, q projective) projective {
+	a, b3 := obj.mode.A, obj.b3
+	t0 := obj.mul(p.X, q.X)
+	t1 := obj.mul(p.Y, q.Y)
+	t2 := obj.mul(p.Z, q.Z)
+	t3 := obj.mul(obj.add(p.X, p.Y), obj.add(q.X, q.Y))
+	t3 = obj.sub(t3, obj.add(t0, t1))
+	t4 := obj.mul(obj.add(p.X, p.Z), obj.add(q.X, q.Z))
+	t4 = obj.sub(t4, obj.add(t0, t2))
+	t5 := obj.mul(obj.add(p.Y, p.Z), obj.add(q.Y, q.Z))
+	t5 = obj.sub(t5, obj.add(t1, t2))
+	z3 := obj.add(obj.mul(a, t4), obj.mul(b3, t2))
+	x3 := obj.sub(t1, z3)
+	z3 = obj.add(t1, z3)
+	y3 := obj.mul(x3, z3)
+	t1 = obj.add(obj.add(t0, t0), t0)
+	t2 = obj.mul(a, t2)
+	t4 = obj.mul(b3, t4)
+	t1 = obj.add(t1, t2)
+	t2 = obj.mul(a, obj.sub(t0, t2))
+	t4 = obj.add(t4, t2)
+	y3 = obj.add(y3, obj.mul(t1, t4))
+	x3 = obj.sub(obj.mul(t3, x3), obj.mul(t5, t4))
+	z3 = obj.add(obj.mul(t5, z3), obj.mul(t3, t1))
+	return obj.reduce(x3, y3, z3)
+}
+
+// doubleGeneric is algorithm 3, for any A
+func (obj completeCurve) doubleGeneric(p projective) projective {
+	a, b3 := obj.mode.A, obj.b3
+	t0 := obj.mul(p.X, p.X)
+	t1 := obj.mul(p.Y, p.Y)
+	t2 := obj.mul(p.Z, p.Z)
+	t3 := obj.mul(p.X, p.Y)
+	t3 = obj.add(t3, t3)
+	z3 := obj.mul(p.X, p.Z)
+	z3 = obj.add(z3, z3)
+	x3 := obj.mul(a, z3)
+	y3 := obj.add(x3, obj.mul(b3, t2))
+	x3 = obj.sub(t1, y3)
+	y3 = obj.mul(x3, obj.add(t1, y3))
+	x3 = obj.mul(t3, x3)
+	z3 = obj.mul(b3, z3)
+	t2 = obj.mul(a, t2)
+	t3 = obj.add(obj.mul(a, obj.sub(t0, t2)), z3)
+	t0 = obj.add(obj.add(obj.add(t0, t0), t0), t2)
+	y3 = obj.add(y3, obj.mul(t0, t3))
+	t2 = obj.mul(p.Y, p.Z)
+	t2 = obj.add(t2, t2)
+	x3 = obj.sub(x3, obj.mul(t2, t3))
+	z3 = obj.mul(t2, t1)
+	z3 = obj.add(z3, z3)
+	z3 = obj.add(z3, z3)
+	return obj.reduce(x3, y3, z3)
+}
+
+// addAMinus3 is algorithm 4, for A = -3
+func (obj completeCurve) addAMinus3(p projective, q projective) projective {
+	b := obj.b
+	t0 := obj.mul(p.X, q.X)
+	t1 := obj.mul(p.Y, q.Y)
+	t2 := obj.mul(p.Z, q.Z)
+	t3 := obj.mul(obj.add(p.X, p.Y), obj.add(q.X, q.Y))
+	t3 = obj.sub(t3, obj.add(t0, t1))
+	t4 := obj.mul(obj.add(p.Y, p.Z), obj.add(q.Y, q.Z))
+	t4 = obj.sub(t4, obj.add(t1, t2))
+	y3 := obj.mul(obj.add(p.X, p.Z), obj.add(q.X, q.Z))
+	y3 = obj.sub(y3, obj.add(t0, t2))
+	x3 := obj.sub(y3, obj.mul(b, t2))
+	x3 = obj.add(obj.add(x3, x3), x3)
+	z3 := obj.sub(t1, x3)
+	x3 = obj.add(t1, x3)
+	y3 = obj.mul(b, y3)
+	t2 = obj.add(obj.add(t2, t2), t2)
+	y3 = obj.sub(obj.sub(y3, t2), t0)
+	y3 = obj.add(obj.add(y3, y3), y3)
+	t0 = obj.sub(obj.add(obj.add(t0, t0), t0), t2)
+	t1 = obj.mul(t4, y3)
+	t2 = obj.mul(t0, y3)
+	y3 = obj.add(obj.mul(x3, z3), t2)
+	x3 = obj.sub(obj.mul(t3, x3), t1)
+	z3 = obj.add(obj.mul(t4, z3), obj.mul(t3, t0))
+	return obj.reduce(x3, y3, z3)
+}
+
+// doubleAMinus3 is algorithm 6, for A = -3
+func (obj completeCurve) doubleAMinus3(p projective) projective {
+	b := obj.b
+	t0 := obj.mul(p.X, p.X)
+	t1 := obj.mul(p.Y, p.Y)
+	t2 := obj.mul(p.Z, p.Z)
+	t3 := obj.mul(p.X, p.Y)
+	t3 = obj.add(t3, t3)
+	z3 := obj.mul(p.X, p.Z)
+	z3 = obj.add(z3, z3)
+	y3 := obj.sub(obj.mul(b, t2), z3)
+	y3 = obj.add(obj.add(y3, y3), y3)
+	x3 := obj.sub(t1, y3)
+	y3 = obj.mul(x3, obj.add(t1, y3))
+	x3 = obj.mul(x3, t3)
+	t2 = obj.add(obj.add(t2, t2), t2)
+	z3 = obj.sub(obj.sub(obj.mul(b, z3), t2), t0)
+	z3 = obj.add(obj.add(z3, z3), z3)
+	t0 = obj.sub(obj.add(obj.add(t0, t0), t0), t2)
+	y3 = obj.add(y3, obj.mul(t0, z3))
+	t0 = obj.mul(p.Y, p.Z)
+	t0 = obj.add(t0, t0)
+	x3 = obj.sub(x3, obj.mul(t0, z3))
+	z3 = obj.mul(t0, t1)
+	z3 = obj.add(z3, z3)
+	z3 = obj.add(z3, z3)
+	return obj.reduce(x3, y3, z3)
+}
+
+// addAZero is algorithm 7, for A = 0
+func (obj completeCurve) addAZero(p projective, q projective) projective {
+	b3 := obj.b3
+	t0 := obj.mul(p.X, q.X)
+	t1 := obj.mul(p.Y, q.Y)
+	t2 := obj.mul(p.Z, q.Z)
+	t3 := obj.mul(obj.add(p.X, p.Y), obj.add(q.X, q.Y))
+	t3 = obj.sub(t3, obj.add(t0, t1))
+	t4 := obj.mul(obj.add(p.Y, p.Z), obj.add(q.Y, q.Z))
+	t4 = obj.sub(t4, obj.add(t1, t2))
+	y3 := obj.mul(obj.add(p.X, p.Z), obj.add(q.X, q.Z))
+	y3 = obj.sub(y3, obj.add(t0, t2))
+	t0 = obj.add(obj.add(t0, t0), t0)
+	t2 = obj.mul(b3, t2)
+	z3 := obj.add(t1, t2)
+	t1 = obj.sub(t1, t2)
+	y3 = obj.mul(b3, y3)
+	x3 := obj.sub(obj.mul(t3, t1), obj.mul(t4, y3))
+	y3 = obj.add(obj.mul(t1, z3), obj.mul(y3, t0))
+	z3 = obj.add(obj.mul(z3, t4), obj.mul(t0, t3))
+	return obj.reduce(x3, y3, z3)
+}
+
+// doubleAZero is algorithm 9, for A = 0
+func (obj completeCurve) doubleAZero(p projective) projective {
+	b3 := obj.b3
+	t0 := obj.mul(p.Y, p.Y)
+	z3 := obj.add(t0, t0)
+	z3 = obj.add(z3, z3)
+	z3 = obj.add(z3, z3)
+	t1 := obj.mul(p.Y, p.Z)
+	t2 := obj.mul(b3, obj.mul(p.Z, p.Z))
+	x3 := obj.mul(t2, z3)
+	y3 := obj.add(t0, t2)
+	z3 = obj.mul(t1, z3)
+	t2 = obj.add(obj.add(t2, t2), t2)
+	t0 = obj.sub(t0, t2)
+	y3 = obj.add(x3, obj.mul(t0, y3))
+	x3 = obj.mul(t0, obj.mul(p.X, p.Y))
+	x3 = obj.add(x3, x3)
+	return obj.reduce(x3, y3, z3)
+}
+
+// selectProjective returns a when flag is 1 and b when it's 0, for coordinates of at
+// most the given number of words
+func selectProjective(flag big.Word, a projective, b projective, words int) projective {
+	coordinates := [3][2]*big.Int{{a.X, b.X}, {a.Y, b.Y}, {a.Z, b.Z}}
+	var selected [3]*big.Int
+	for i, pair := range coordinates {
+		z := make([]big.Word, words)
+		copy(z, pair[1].Bits())
+		selectWords(flag, z, pair[0].Bits())
+		selected[i] = new(big.Int).SetBits(z)
+	}
+	return projective{X: selected[0], Y: selected[1], Z: selected[2]}
+}
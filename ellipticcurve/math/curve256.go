@@ -0,0 +1,664 @@
+package math
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// Backend is a fixed-limb implementation of one curve's scalar multiplications. A
+// CurveFp with a Backend uses it in place of the generic big.Int arithmetic, and
+// gets exactly the same points back.
+type Backend interface {
+	Multiply(p point.Point, n *big.Int) point.Point
+	// MultiplyGenerator computes n*G, starting from the projective Z given, if any
+	MultiplyGenerator(n *big.Int, z ...*big.Int) point.Point
+	MultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int) point.Point
+	// Prepare precomputes the multiples of p used by MultiplyGeneratorAndAdd
+	Prepare(p point.Point) PreparedPoint
+	// MultiplyGeneratorAndAdd computes n1*G + n2*p, for public scalars only
+	MultiplyGeneratorAndAdd(n1 *big.Int, p PreparedPoint, n2 *big.Int) point.Point
+}
+
+// jacobian256 is a point (X/Z^2, Y/Z^3), at infinity when Z = 0
+type jacobian256 struct {
+	x, y, z element256
+}
+
+// affine256 is a point with Z = 1, for the mixed additions of the generator table
+type affine256 struct {
+	x, y element256
+}
+
+// backend256 runs a prime-order curve y^2 = x^3 + A*x + B with A = 0 or A = -3 over a
+// field256. Multiply and MultiplyGenerator take the same time for any scalar;
+// MultiplyAndAdd, which only sees public values when verifying, uses wNAF and, when
+// the curve has one, the GLV endomorphism.
+type backend256 struct {
+	field     *field256
+	aIsMinus3 bool
+	g         point.Point
+	n         *big.Int
+	glv       *GLVParams
+	beta      element256
+
+	once sync.Once
+	// table[i][j] is (j + 1)*16^i*G, for the 4-bit windows of a scalar, blinded or not
+	table [][15]affine256
+
+	preparedOnce sync.Once
+	preparedG    *prepared256
+}
+
+func newBackend256(field *field256, aIsMinus3 bool, G point.Point, N *big.Int, glv *GLVParams) *backend256 {
+	obj := &backend256{field: field, aIsMinus3: aIsMinus3, g: G, n: N, glv: glv}
+	if glv != nil {
+		obj.beta = field.fromBig(glv.Beta)
+	}
+	return obj
+}
+
+func (obj *backend256) fromPoint(p point.Point) jacobian256 {
+	if p.IsAtInfinity() {
+		return jacobian256{}
+	}
+	return jacobian256{x: obj.field.fromBig(p.X), y: obj.field.fromBig(p.Y), z: obj.field.one}
+}
+
+// toPoint returns the affine point, or point.AtInfinity when z = 0
+func (obj *backend256) toPoint(p *jacobian256) point.Point {
+	if p.z.isZero() == 1 {
+		return point.AtInfinity()
+	}
+	f := obj.field
+	var zInv, zInv2, x, y element256
+	f.inv(&zInv, &p.z)
+	f.square(&zInv2, &zInv)
+	f.mul(&x, &p.x, &zInv2)
+	f.mul(&y, &p.y, &zInv2)
+	f.mul(&y, &y, &zInv)
+	return point.Point{X: f.toBig(x), Y: f.toBig(y)}
+}
+
+// double sets r to 2*p. Z stays 0 at infinity, and a prime-order curve has no point of
+// order 2, so no other input needs a special case.
+func (obj *backend256) double(r *jacobian256, p *jacobian256) {
+	if obj.aIsMinus3 {
+		obj.doubleMinus3(r, p)
+		return
+	}
+
+	// dbl-2009-l, for A = 0
+	f := obj.field
+	var a, b, c, d, e, ee, t element256
+	f.square(&a, &p.x)
+	f.square(&b, &p.y)
+	f.square(&c, &b)
+	// D = 2*((X + B)^2 - A - C)
+	f.add(&d, &p.x, &b)
+	f.square(&d, &d)
+	f.sub(&d, &d, &a)
+	f.sub(&d, &d, &c)
+	f.add(&d, &d, &d)
+	// E = 3*A, F = E^2
+	f.add(&e, &a, &a)
+	f.add(&e, &e, &a)
+	f.square(&ee, &e)
+
+	// Z3 = 2*Y*Z, before p.y is overwritten when r is p
+	f.mul(&r.z, &p.y, &p.z)
+	f.add(&r.z, &r.z, &r.z)
+	// X3 = F - 2*D
+	f.sub(&r.x, &ee, &d)
+	f.sub(&r.x, &r.x, &d)
+	// Y3 = E*(D - X3) - 8*C
+	f.sub(&t, &d, &r.x)
+	f.mul(&r.y, &e, &t)
+	f.add(&c, &c, &c)
+	f.add(&c, &c, &c)
+	f.add(&c, &c, &c)
+	f.sub(&r.y, &r.y, &c)
+}
+
+// doubleMinus3 is double for A = -3 (dbl-2001-b)
+func (obj *backend256) doubleMinus3(r *jacobian256, p *jacobian256) {
+	f := obj.field
+	var delta, gamma, beta, alpha, t, u element256
+	f.square(&delta, &p.z)
+	f.square(&gamma, &p.y)
+	f.mul(&beta, &p.x, &gamma)
+	// alpha = 3*(X - delta)*(X + delta)
+	f.sub(&t, &p.x, &delta)
+	f.add(&u, &p.x, &delta)
+	f.mul(&alpha, &t, &u)
+	f.add(&t, &alpha, &alpha)
+	f.add(&alpha, &t, &alpha)
+
+	// Z3 = (Y + Z)^2 - gamma - delta, before p.y is overwritten when r is p
+	f.add(&t, &p.y, &p.z)
+	f.square(&t, &t)
+	f.sub(&t, &t, &gamma)
+	f.sub(&r.z, &t, &delta)
+	// X3 = alpha^2 - 8*beta
+	f.add(&beta, &beta, &beta)
+	f.add(&beta, &beta, &beta)
+	f.square(&r.x, &alpha)
+	f.sub(&r.x, &r.x, &beta)
+	f.sub(&r.x, &r.x, &beta)
+	// Y3 = alpha*(4*beta - X3) - 8*gamma^2
+	f.sub(&t, &beta, &r.x)
+	f.mul(&r.y, &alpha, &t)
+	f.square(&gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.add(&gamma, &gamma, &gamma)
+	f.sub(&r.y, &r.y, &gamma)
+}
+
+// addUnchecked sets r to p + q (add-2007-bl) when neither is at infinity and they
+// aren't equal or opposite. It returns 1 when the x coordinates match, i.e. when the
+// result is wrong because p = q or p = -q, and whether the y coordinates match too.
+func (obj *backend256) addUnchecked(r *jacobian256, p *jacobian256, q *jacobian256) (sameX uint64, sameY uint64) {
+	f := obj.field
+	var z1z1, z2z2, u1, u2, s1, s2, h, i, j, rr, v, t element256
+	f.square(&z1z1, &p.z)
+	f.square(&z2z2, &q.z)
+	f.mul(&u1, &p.x, &z2z2)
+	f.mul(&u2, &q.x, &z1z1)
+	f.mul(&s1, &p.y, &q.z)
+	f.mul(&s1, &s1, &z2z2)
+	f.mul(&s2, &q.y, &p.z)
+	f.mul(&s2, &s2, &z1z1)
+	f.sub(&h, &u2, &u1)
+	f.sub(&rr, &s2, &s1)
+	sameX, sameY = h.isZero(), rr.isZero()
+
+	// I = (2*H)^2, J = H*I, r = 2*(S2 - S1), V = U1*I
+	f.add(&i, &h, &h)
+	f.square(&i, &i)
+	f.mul(&j, &h, &i)
+	f.add(&rr, &rr, &rr)
+	f.mul(&v, &u1, &i)
+
+	// Z3 = ((Z1 + Z2)^2 - Z1Z1 - Z2Z2)*H
+	f.add(&t, &p.z, &q.z)
+	f.square(&t, &t)
+	f.sub(&t, &t, &z1z1)
+	f.sub(&t, &t, &z2z2)
+	f.mul(&r.z, &t, &h)
+	// X3 = r^2 - J - 2*V
+	f.square(&r.x, &rr)
+	f.sub(&r.x, &r.x, &j)
+	f.sub(&r.x, &r.x, &v)
+	f.sub(&r.x, &r.x, &v)
+	// Y3 = r*(V - X3) - 2*S1*J
+	f.sub(&t, &v, &r.x)
+	f.mul(&r.y, &rr, &t)
+	f.mul(&s1, &s1, &j)
+	f.add(&s1, &s1, &s1)
+	f.sub(&r.y, &r.y, &s1)
+	return sameX, sameY
+}
+
+// addMixedUnchecked is addUnchecked for an affine q (madd-2007-bl)
+func (obj *backend256) addMixedUnchecked(r *jacobian256, p *jacobian256, q *affine256) (sameX uint64, sameY uint64) {
+	f := obj.field
+	var z1z1, u2, s2, h, hh, i, j, rr, v, t, y1j element256
+	f.square(&z1z1, &p.z)
+	f.mul(&u2, &q.x, &z1z1)
+	f.mul(&s2, &q.y, &p.z)
+	f.mul(&s2, &s2, &z1z1)
+	f.sub(&h, &u2, &p.x)
+	f.square(&hh, &h)
+	// I = 4*HH, J = H*I, r = 2*(S2 - Y1), V = X1*I
+	f.add(&i, &hh, &hh)
+	f.add(&i, &i, &i)
+	f.mul(&j, &h, &i)
+	f.sub(&rr, &s2, &p.y)
+	sameX, sameY = h.isZero(), rr.isZero()
+	f.add(&rr, &rr, &rr)
+	f.mul(&v, &p.x, &i)
+
+	// Y3 = r*(V - X3) - 2*Y1*J, with Y1*J first since r may be p
+	f.mul(&y1j, &p.y, &j)
+	f.add(&y1j, &y1j, &y1j)
+	// Z3 = (Z1 + H)^2 - Z1Z1 - HH
+	f.add(&t, &p.z, &h)
+	f.square(&t, &t)
+	f.sub(&t, &t, &z1z1)
+	f.sub(&r.z, &t, &hh)
+	// X3 = r^2 - J - 2*V
+	f.square(&r.x, &rr)
+	f.sub(&r.x, &r.x, &j)
+	f.sub(&r.x, &r.x, &v)
+	f.sub(&r.x, &r.x, &v)
+	f.sub(&t, &v, &r.x)
+	f.mul(&r.y, &rr, &t)
+	f.sub(&r.y, &r.y, &y1j)
+	return sameX, sameY
+}
+
+// add sets r to p + q for any p and q, branching on the special cases. It's for
+// public inputs only, such as signature verification.
+func (obj *backend256) add(r *jacobian256, p *jacobian256, q *jacobian256) {
+	if p.z.isZero() == 1 {
+		*r = *q
+		return
+	}
+	if q.z.isZero() == 1 {
+		*r = *p
+		return
+	}
+	var sum jacobian256
+	sameX, sameY := obj.addUnchecked(&sum, p, q)
+	if sameX == 1 {
+		if sameY == 1 {
+			obj.double(r, p)
+		} else {
+			*r = jacobian256{}
+		}
+		return
+	}
+	*r = sum
+}
+
+// addMixed is add for an affine q
+func (obj *backend256) addMixed(r *jacobian256, p *jacobian256, q *affine256) {
+	if p.z.isZero() == 1 {
+		*r = jacobian256{x: q.x, y: q.y, z: obj.field.one}
+		return
+	}
+	f := obj.field
+	var z1z1, u2, s2 element256
+	f.square(&z1z1, &p.z)
+	f.mul(&u2, &q.x, &z1z1)
+	f.mul(&s2, &q.y, &p.z)
+	f.mul(&s2, &s2, &z1z1)
+	if u2 == p.x {
+		if s2 == p.y {
+			obj.double(r, p)
+		} else {
+			*r = jacobian256{}
+		}
+		return
+	}
+	obj.addMixedUnchecked(r, p, q)
+}
+
+// addConstantTime sets r to p + q for any p and q without branching: the sum, the
+// doubling of p and the point at infinity are all computed, and the one that applies
+// to p = q, p = -q or either point at infinity is selected with masks
+func (obj *backend256) addConstantTime(r *jacobian256, p *jacobian256, q *jacobian256) {
+	pInfinity, qInfinity := p.z.isZero(), q.z.isZero()
+	var sum, double jacobian256
+	sameX, sameY := obj.addUnchecked(&sum, p, q)
+	obj.double(&double, p)
+	sum.selectPoint(sameX&sameY, &double)
+	sum.selectPoint(sameX&^sameY, &jacobian256{})
+	sum.selectPoint(pInfinity, q)
+	sum.selectPoint(qInfinity, p)
+	*r = sum
+}
+
+// addMixedConstantTime is addConstantTime for an affine q, at infinity when qInfinity is 1.
+// When p is at infinity, q is lifted to (z^2*x, z^3*y, z) for lift = (z^2, z^3, z), or
+// to (x, y, 1) for a nil lift.
+func (obj *backend256) addMixedConstantTime(r *jacobian256, p *jacobian256, q *affine256, qInfinity uint64, lift *jacobian256) {
+	pInfinity := p.z.isZero()
+	var sum, double jacobian256
+	sameX, sameY := obj.addMixedUnchecked(&sum, p, q)
+	obj.double(&double, p)
+	sum.selectPoint(sameX&sameY, &double)
+	sum.selectPoint(sameX&^sameY, &jacobian256{})
+	lifted := jacobian256{x: q.x, y: q.y, z: obj.field.one}
+	if lift != nil {
+		obj.field.mul(&lifted.x, &q.x, &lift.x)
+		obj.field.mul(&lifted.y, &q.y, &lift.y)
+		lifted.z = lift.z
+	}
+	sum.selectPoint(pInfinity, &lifted)
+	sum.selectPoint(qInfinity, p)
+	*r = sum
+}
+
+func (obj *jacobian256) selectPoint(flag uint64, a *jacobian256) {
+	obj.x.select_(flag, &a.x)
+	obj.y.select_(flag, &a.y)
+	obj.z.select_(flag, &a.z)
+}
+
+func (obj *backend256) neg(r *jacobian256, p *jacobian256) {
+	r.x, r.z = p.x, p.z
+	obj.field.neg(&r.y, &p.y)
+}
+
+// windows256 splits n, below 16^count, into count 4-bit windows, least significant first
+func windows256(n *big.Int, count int) []uint64 {
+	buffer := make([]byte, count/2)
+	n.FillBytes(buffer)
+	windows := make([]uint64, count)
+	for i := 0; i < count/2; i++ {
+		windows[2*i] = uint64(buffer[count/2-1-i] & 0xf)
+		windows[2*i+1] = uint64(buffer[count/2-1-i] >> 4)
+	}
+	return windows
+}
+
+const (
+	// _windows256 is the number of 4-bit windows of a scalar below 2^256
+	_windows256 = 64
+	// _blindedWindows256 also covers the m of a blinded scalar k + m*N
+	_blindedWindows256 = _windows256 + ScalarBlindingBits/4
+)
+
+// equalFlag returns 1 when a = b, and 0 otherwise, without branching
+func equalFlag(a uint64, b uint64) uint64 {
+	x := a ^ b
+	return 1 ^ ((x | -x) >> 63)
+}
+
+func (obj *backend256) scalar(n *big.Int) *big.Int {
+	if n.Sign() < 0 || n.Cmp(obj.n) >= 0 {
+		return new(big.Int).Mod(n, obj.n)
+	}
+	return n
+}
+
+// Multiply computes n*p with a fixed 4-bit window: 256 doublings and 64 additions of a
+// multiple of p picked by a full scan of its 16-entry table
+func (obj *backend256) Multiply(p point.Point, n *big.Int) point.Point {
+	n = obj.scalar(n)
+	base := obj.fromPoint(p)
+	if n.Sign() == 0 || base.z.isZero() == 1 {
+		return point.AtInfinity()
+	}
+
+	var table [16]jacobian256
+	table[1] = base
+	for i := 2; i < 16; i += 2 {
+		obj.double(&table[i], &table[i/2])
+		obj.add(&table[i+1], &table[i], &base)
+	}
+
+	windows := windows256(n, _windows256)
+	var r, entry jacobian256
+	for i := 63; i >= 0; i-- {
+		for j := 0; j < 4; j++ {
+			obj.double(&r, &r)
+		}
+		entry = jacobian256{}
+		for j := 1; j < 16; j++ {
+			entry.selectPoint(equalFlag(uint64(j), windows[i]), &table[j])
+		}
+		obj.addConstantTime(&r, &r, &entry)
+	}
+	return obj.toPoint(&r)
+}
+
+// MultiplyGenerator computes n*G as the sum of one precomputed affine multiple of G
+// per 4-bit window of n: 64 mixed additions, no doublings, and full table scans. A
+// blinded scalar k + m*N, with 0 < m < 2^ScalarBlindingBits, runs unreduced over 80
+// windows. A nonzero z randomizes the Jacobian coordinates of the first nonzero window.
+func (obj *backend256) MultiplyGenerator(n *big.Int, z ...*big.Int) point.Point {
+	if n.Sign() < 0 || n.BitLen() > 4*_blindedWindows256 {
+		n = new(big.Int).Mod(n, obj.n)
+	}
+	if n.Sign() == 0 {
+		return point.AtInfinity()
+	}
+	table := obj.generatorTable()
+	count := _windows256
+	if n.Cmp(obj.n) >= 0 {
+		count = _blindedWindows256
+	}
+	var lift *jacobian256
+	if len(z) > 0 {
+		lift = &jacobian256{z: obj.field.fromBig(projectiveZ(z, obj.field.toBigRaw(obj.field.p)))}
+		obj.field.square(&lift.x, &lift.z)
+		obj.field.mul(&lift.y, &lift.x, &lift.z)
+	}
+
+	windows := windows256(n, count)
+	var r jacobian256
+	var entry affine256
+	for i := 0; i < count; i++ {
+		entry = affine256{}
+		for j := 0; j < 15; j++ {
+			flag := equalFlag(uint64(j+1), windows[i])
+			entry.x.select_(flag, &table[i][j].x)
+			entry.y.select_(flag, &table[i][j].y)
+		}
+		obj.addMixedConstantTime(&r, &r, &entry, equalFlag(0, windows[i]), lift)
+	}
+	return obj.toPoint(&r)
+}
+
+func (obj *backend256) generatorTable() [][15]affine256 {
+	obj.once.Do(func() {
+		points := make([]jacobian256, _blindedWindows256*15)
+		base := obj.fromPoint(obj.g)
+		for i := 0; i < _blindedWindows256; i++ {
+			row := points[15*i : 15*i+15]
+			row[0] = base
+			for j := 1; j < 15; j++ {
+				obj.add(&row[j], &row[j-1], &base)
+			}
+			// 16^(i+1)*G = 15*16^i*G + 16^i*G
+			obj.add(&base, &row[14], &base)
+		}
+
+		affine := obj.batchAffine(points)
+		obj.table = make([][15]affine256, _blindedWindows256)
+		for i := range obj.table {
+			copy(obj.table[i][:], affine[15*i:15*i+15])
+		}
+	})
+	return obj.table
+}
+
+// batchAffine converts points, none at infinity, to affine with a single inversion
+// (Montgomery's trick)
+func (obj *backend256) batchAffine(points []jacobian256) []affine256 {
+	f := obj.field
+	products := make([]element256, len(points))
+	accumulator := f.one
+	for i := range points {
+		products[i] = accumulator
+		f.mul(&accumulator, &accumulator, &points[i].z)
+	}
+	var inverse element256
+	f.inv(&inverse, &accumulator)
+
+	affine := make([]affine256, len(points))
+	for i := len(points) - 1; i >= 0; i-- {
+		// 1/Z_i = (Z_0*...*Z_(i-1)) / (Z_0*...*Z_i)
+		var zInv, zInv2 element256
+		f.mul(&zInv, &inverse, &products[i])
+		f.mul(&inverse, &inverse, &points[i].z)
+		f.square(&zInv2, &zInv)
+		f.mul(&affine[i].x, &points[i].x, &zInv2)
+		f.mul(&affine[i].y, &points[i].y, &zInv2)
+		f.mul(&affine[i].y, &affine[i].y, &zInv)
+	}
+	return affine
+}
+
+// MultiplyAndAdd computes n1*p1 + n2*p2 with Straus' method over width-5 wNAFs of the
+// scalars, first split in two halves each by the GLV endomorphism when the curve has
+// one. It branches on the scalars, which verification only computes from public values.
+func (obj *backend256) MultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int) point.Point {
+	bases := []jacobian256{obj.fromPoint(p1), obj.fromPoint(p2)}
+	scalars := []*big.Int{obj.scalar(n1), obj.scalar(n2)}
+	if obj.glv != nil {
+		k1, k2 := glvDecompose(scalars[0], obj.glv, obj.n)
+		k3, k4 := glvDecompose(scalars[1], obj.glv, obj.n)
+		scalars = []*big.Int{k1, k2, k3, k4}
+		// phi((x, y)) = (beta*x, y) = lambda*(x, y)
+		bases = []jacobian256{bases[0], bases[0], bases[1], bases[1]}
+		obj.field.mul(&bases[1].x, &bases[1].x, &obj.beta)
+		obj.field.mul(&bases[3].x, &bases[3].x, &obj.beta)
+	}
+
+	tables := make([][_wnafTableSize]jacobian256, len(bases))
+	digits := make([][]int8, len(bases))
+	length := 0
+	for i := range bases {
+		if scalars[i].Sign() < 0 {
+			scalars[i] = new(big.Int).Neg(scalars[i])
+			obj.neg(&bases[i], &bases[i])
+		}
+		if bases[i].z.isZero() == 1 {
+			scalars[i] = big.NewInt(0)
+		}
+		digits[i] = wnaf(scalars[i], _wnafWidth)
+		if len(digits[i]) > length {
+			length = len(digits[i])
+		}
+
+		// odd multiples P, 3P, 5P, ..., 15P
+		var double jacobian256
+		obj.double(&double, &bases[i])
+		tables[i][0] = bases[i]
+		for j := 1; j < _wnafTableSize; j++ {
+			obj.add(&tables[i][j], &tables[i][j-1], &double)
+		}
+	}
+
+	var r, negative jacobian256
+	for bit := length - 1; bit >= 0; bit-- {
+		obj.double(&r, &r)
+		for i := range digits {
+			if bit >= len(digits[i]) {
+				continue
+			}
+			if digit := digits[i][bit]; digit > 0 {
+				obj.add(&r, &r, &tables[i][digit/2])
+			} else if digit < 0 {
+				obj.neg(&negative, &tables[i][-digit/2])
+				obj.add(&r, &r, &negative)
+			}
+		}
+	}
+	return obj.toPoint(&r)
+}
+
+// prepared256 is the PreparedPoint of a backend256: the affine odd multiples P, 3P,
+// ..., 63P of a point and, on curves with a GLV endomorphism, their images phi.
+// tables is nil at infinity.
+type prepared256 struct {
+	point  point.Point
+	tables [][_preparedTableSize]affine256
+}
+
+func (obj *prepared256) Point() point.Point {
+	return obj.point
+}
+
+func (obj *backend256) Prepare(p point.Point) PreparedPoint {
+	prepared := &prepared256{point: p}
+	base := obj.fromPoint(p)
+	if base.z.isZero() == 1 {
+		return prepared
+	}
+
+	// no multiple of a point below N is at infinity on a prime-order curve
+	var double jacobian256
+	obj.double(&double, &base)
+	multiples := make([]jacobian256, _preparedTableSize)
+	multiples[0] = base
+	for j := 1; j < len(multiples); j++ {
+		obj.add(&multiples[j], &multiples[j-1], &double)
+	}
+	var table [_preparedTableSize]affine256
+	copy(table[:], obj.batchAffine(multiples))
+	prepared.tables = append(prepared.tables, table)
+
+	if obj.glv != nil {
+		// phi((x, y)) = (beta*x, y)
+		images := table
+		for j := range images {
+			obj.field.mul(&images[j].x, &images[j].x, &obj.beta)
+		}
+		prepared.tables = append(prepared.tables, images)
+	}
+	return prepared
+}
+
+// MultiplyGeneratorAndAdd computes n1*G + n2*p like MultiplyAndAdd, over width-7 wNAFs
+// and the affine tables of G, cached on the backend, and of p
+func (obj *backend256) MultiplyGeneratorAndAdd(n1 *big.Int, p PreparedPoint, n2 *big.Int) point.Point {
+	obj.preparedOnce.Do(func() {
+		obj.preparedG = obj.Prepare(obj.g).(*prepared256)
+	})
+	prepared, ok := p.(*prepared256)
+	if !ok {
+		prepared = obj.Prepare(p.Point()).(*prepared256)
+	}
+
+	var tables []*[_preparedTableSize]affine256
+	var digits [][]int8
+	for i, prepared := range []*prepared256{obj.preparedG, prepared} {
+		if prepared.tables == nil {
+			continue
+		}
+		scalars := []*big.Int{obj.scalar([]*big.Int{n1, n2}[i])}
+		if obj.glv != nil {
+			k1, k2 := glvDecompose(scalars[0], obj.glv, obj.n)
+			scalars = []*big.Int{k1, k2}
+		}
+		for j, k := range scalars {
+			tables = append(tables, &prepared.tables[j])
+			digits = append(digits, signedWnaf(k, _preparedWnafWidth))
+		}
+	}
+
+	var r jacobian256
+	var negative affine256
+	for bit := maxLength(digits) - 1; bit >= 0; bit-- {
+		obj.double(&r, &r)
+		for i := range digits {
+			if bit >= len(digits[i]) {
+				continue
+			}
+			if digit := digits[i][bit]; digit > 0 {
+				obj.addMixed(&r, &r, &tables[i][digit/2])
+			} else if digit < 0 {
+				negative = tables[i][-digit/2]
+				obj.field.neg(&negative.y, &negative.y)
+				obj.addMixed(&r, &r, &negative)
+			}
+		}
+	}
+	return obj.toPoint(&r)
+}
+
+// wnaf returns the width-w NAF of k >= 0, least significant digit first: odd digits
+// below 2^(w-1) in absolute value, with at least w - 1 zeros after each
+func wnaf(k *big.Int, w int) []int8 {
+	digits := make([]int8, k.BitLen()+1)
+	carry := 0
+	for bit := 0; bit < len(digits); {
+		if int(k.Bit(bit)) == carry {
+			bit++
+			continue
+		}
+		width := w
+		if width > len(digits)-bit {
+			width = len(digits) - bit
+		}
+		word := carry
+		for j := 0; j < width; j++ {
+			word += int(k.Bit(bit+j)) << j
+		}
+		carry = (word >> (w - 1)) & 1
+		word -= carry << w
+		digits[bit] = int8(word)
+		bit += width
+	}
+	return digits
+}
+
+const _wnafWidth = 5
+
+const _wnafTableSize = 1 << (_wnafWidth - 2)
@@ -0,0 +1,254 @@
+package math
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// element256 is a field element as little-endian 64-bit limbs. Every operation of its
+// field256 leaves it fully reduced, in [0, p).
+type element256 [4]uint64
+
+// field256 does arithmetic modulo a prime p of at most 256 bits on element256s,
+// without allocating. Primes 2^256 - c with a small c (secp256k1) fold the high
+// half of products back in with 2^256 = c; other primes (P-256) keep elements in
+// Montgomery form, a*2^256 mod p, and use Montgomery reduction.
+type field256 struct {
+	p     element256
+	c     uint64     // 2^256 - p for pseudo-Mersenne primes, 0 for Montgomery form
+	pInv  uint64     // -p^-1 mod 2^64, for Montgomery reduction
+	rr    element256 // 2^512 mod p, to convert into Montgomery form
+	one   element256 // 1 in the field's representation
+	chain int        // the addition chain of inv
+}
+
+const (
+	_secp256k1Chain = iota
+	_p256Chain
+)
+
+func newField256(P *big.Int, chain int) *field256 {
+	obj := &field256{chain: chain, one: element256{1}}
+	obj.p = limbs256(P)
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	if c := new(big.Int).Sub(r, P); c.BitLen() <= 34 {
+		obj.c = c.Uint64()
+		return obj
+	}
+
+	// Newton's iteration doubles the correct low bits of p^-1 mod 2^64 at every step
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - obj.p[0]*inv
+	}
+	obj.pInv = -inv
+	obj.one = limbs256(new(big.Int).Mod(r, P))
+	obj.rr = limbs256(new(big.Int).Mod(new(big.Int).Mul(r, r), P))
+	return obj
+}
+
+// limbs256 splits 0 <= value < 2^256 into limbs
+func limbs256(value *big.Int) element256 {
+	var buffer [32]byte
+	value.FillBytes(buffer[:])
+	var z element256
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			z[i] |= uint64(buffer[31-8*i-j]) << (8 * j)
+		}
+	}
+	return z
+}
+
+func (obj *field256) fromBig(value *big.Int) element256 {
+	P := obj.toBigRaw(obj.p)
+	if value.Sign() < 0 || value.Cmp(P) >= 0 {
+		value = new(big.Int).Mod(value, P)
+	}
+	z := limbs256(value)
+	if obj.c == 0 {
+		obj.mul(&z, &z, &obj.rr)
+	}
+	return z
+}
+
+func (obj *field256) toBig(a element256) *big.Int {
+	if obj.c == 0 {
+		// a*R * 1 / R = a
+		obj.mul(&a, &a, &element256{1})
+	}
+	return obj.toBigRaw(a)
+}
+
+func (obj *field256) toBigRaw(a element256) *big.Int {
+	var buffer [32]byte
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 8; j++ {
+			buffer[31-8*i-j] = byte(a[i] >> (8 * j))
+		}
+	}
+	return new(big.Int).SetBytes(buffer[:])
+}
+
+// reduce subtracts p from the 257-bit value carry*2^256 + z when it's at least p
+func (obj *field256) reduce(z *element256, carry uint64) {
+	var t element256
+	var borrow uint64
+	t[0], borrow = bits.Sub64(z[0], obj.p[0], 0)
+	t[1], borrow = bits.Sub64(z[1], obj.p[1], borrow)
+	t[2], borrow = bits.Sub64(z[2], obj.p[2], borrow)
+	t[3], borrow = bits.Sub64(z[3], obj.p[3], borrow)
+	// keep z only when z < p: no carry in and a borrow out
+	keep := borrow &^ carry
+	z.select_(keep^1, &t)
+}
+
+// select_ sets z to a when flag is 1, and leaves it when flag is 0, without branching
+func (z *element256) select_(flag uint64, a *element256) {
+	mask := -flag
+	for i := range z {
+		z[i] ^= mask & (z[i] ^ a[i])
+	}
+}
+
+// isZero returns 1 when z is 0, and 0 otherwise
+func (z *element256) isZero() uint64 {
+	acc := z[0] | z[1] | z[2] | z[3]
+	return 1 ^ ((acc | -acc) >> 63)
+}
+
+func (obj *field256) add(z *element256, a *element256, b *element256) {
+	var carry uint64
+	z[0], carry = bits.Add64(a[0], b[0], 0)
+	z[1], carry = bits.Add64(a[1], b[1], carry)
+	z[2], carry = bits.Add64(a[2], b[2], carry)
+	z[3], carry = bits.Add64(a[3], b[3], carry)
+	obj.reduce(z, carry)
+}
+
+func (obj *field256) sub(z *element256, a *element256, b *element256) {
+	var borrow, carry uint64
+	z[0], borrow = bits.Sub64(a[0], b[0], 0)
+	z[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	z[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	z[3], borrow = bits.Sub64(a[3], b[3], borrow)
+	// add p back after a borrow
+	mask := -borrow
+	z[0], carry = bits.Add64(z[0], obj.p[0]&mask, 0)
+	z[1], carry = bits.Add64(z[1], obj.p[1]&mask, carry)
+	z[2], carry = bits.Add64(z[2], obj.p[2]&mask, carry)
+	z[3], _ = bits.Add64(z[3], obj.p[3]&mask, carry)
+}
+
+func (obj *field256) neg(z *element256, a *element256) {
+	var zero element256
+	obj.sub(z, &zero, a)
+}
+
+// mul sets z to a*b: the product of elements in Montgomery form, a*R * b*R / R, is
+// the Montgomery form of a*b too
+func (obj *field256) mul(z *element256, a *element256, b *element256) {
+	var t [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		t[i+4] = carry
+	}
+	if obj.c != 0 {
+		obj.reducePseudoMersenne(z, &t)
+	} else {
+		obj.reduceMontgomery(z, &t)
+	}
+}
+
+// reducePseudoMersenne sets z to t mod p, for p = 2^256 - c
+func (obj *field256) reducePseudoMersenne(z *element256, t *[8]uint64) {
+	c := obj.c
+	// t = low + high*2^256 = low + high*c (mod p), first into five limbs
+	h0, l0 := bits.Mul64(t[4], c)
+	h1, l1 := bits.Mul64(t[5], c)
+	h2, l2 := bits.Mul64(t[6], c)
+	h3, l3 := bits.Mul64(t[7], c)
+	var r0, r1, r2, r3, r4, carry uint64
+	r0, carry = bits.Add64(t[0], l0, 0)
+	r1, carry = bits.Add64(t[1], l1, carry)
+	r2, carry = bits.Add64(t[2], l2, carry)
+	r3, carry = bits.Add64(t[3], l3, carry)
+	r4 = carry
+	r1, carry = bits.Add64(r1, h0, 0)
+	r2, carry = bits.Add64(r2, h1, carry)
+	r3, carry = bits.Add64(r3, h2, carry)
+	r4 += h3 + carry
+
+	// then the fifth limb, below 2^36, the same way
+	h, l := bits.Mul64(r4, c)
+	r0, carry = bits.Add64(r0, l, 0)
+	r1, carry = bits.Add64(r1, h, carry)
+	r2, carry = bits.Add64(r2, 0, carry)
+	r3, carry = bits.Add64(r3, 0, carry)
+	// a last wrap around 2^256 leaves a small value, so adding c can't wrap again
+	r0, carry = bits.Add64(r0, carry*c, 0)
+	r1, carry = bits.Add64(r1, 0, carry)
+	r2, carry = bits.Add64(r2, 0, carry)
+	r3, _ = bits.Add64(r3, 0, carry)
+
+	*z = element256{r0, r1, r2, r3}
+	obj.reduce(z, 0)
+}
+
+// reduceMontgomery sets z to t/2^256 mod p, for t < p*2^256
+func (obj *field256) reduceMontgomery(z *element256, t *[8]uint64) {
+	var top uint64
+	for i := 0; i < 4; i++ {
+		// adding m*p clears limb i
+		m := t[i] * obj.pInv
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(m, obj.p[j])
+			var c uint64
+			lo, c = bits.Add64(lo, t[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[i+j] = lo
+			carry = hi
+		}
+		for k := i + 4; k < 8; k++ {
+			t[k], carry = bits.Add64(t[k], carry, 0)
+		}
+		top += carry
+	}
+
+	*z = element256{t[4], t[5], t[6], t[7]}
+	obj.reduce(z, top)
+}
+
+func (obj *field256) square(z *element256, a *element256) {
+	obj.mul(z, a, a)
+}
+
+// squareN sets z to a^(2^n)
+func (obj *field256) squareN(z *element256, a *element256, n int) {
+	*z = *a
+	for i := 0; i < n; i++ {
+		obj.square(z, z)
+	}
+}
+
+// inv sets z to a^(p - 2) = 1/a through the field's fixed addition chain, whatever a is
+func (obj *field256) inv(z *element256, a *element256) {
+	if obj.chain == _p256Chain {
+		obj.invP256(z, a)
+	} else {
+		obj.invSecp256k1(z, a)
+	}
+}
@@ -0,0 +1,98 @@
+package math
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// isomorphism maps y^2 = x^3 + A*x + B onto the curve y^2 = x^3 - 3*x + B*u^6
+// through (x, y) -> (u^2*x, u^3*y), where u^4 = -3/A (mod P). Running the
+// Jacobian primitives on the image lets curves with an arbitrary A (e.g. the
+// brainpool r1 curves) take the A = -3 doubling shortcut, at the cost of a
+// couple of multiplications per point entering or leaving a computation.
+type isomorphism struct {
+	u2    *big.Int
+	u3    *big.Int
+	u2Inv *big.Int
+	u3Inv *big.Int
+}
+
+// isomorphisms caches isomorphismFor results by curve, since finding u takes
+// two modular square roots. A nil entry records that no isomorphism exists.
+var isomorphisms sync.Map
+
+// isomorphismFor returns the map onto an A = -3 curve, or nil when -3/A is not
+// a fourth power modulo P and no such curve is isomorphic over the field.
+func isomorphismFor(A *big.Int, P *big.Int) *isomorphism {
+	key := A.Text(16) + ":" + P.Text(16)
+	if cached, ok := isomorphisms.Load(key); ok {
+		return cached.(*isomorphism)
+	}
+	iso := findIsomorphism(A, P)
+	isomorphisms.Store(key, iso)
+	return iso
+}
+
+func findIsomorphism(A *big.Int, P *big.Int) *isomorphism {
+	if !P.ProbablyPrime(20) || A.Sign() == 0 {
+		return nil
+	}
+	// u^4 = -3/A: take a square root r of -3/A, then a square root of r or -r
+	c := new(big.Int).Mul(big.NewInt(-3), new(big.Int).ModInverse(A, P))
+	c.Mod(c, P)
+	r := squareRoot(c, P)
+	if r == nil {
+		return nil
+	}
+	u := squareRoot(r, P)
+	if u == nil {
+		u = squareRoot(new(big.Int).Sub(P, r), P)
+	}
+	if u == nil {
+		return nil
+	}
+
+	u2 := new(big.Int).Mul(u, u)
+	u2.Mod(u2, P)
+	u3 := new(big.Int).Mul(u2, u)
+	u3.Mod(u3, P)
+	return &isomorphism{
+		u2:    u2,
+		u3:    u3,
+		u2Inv: new(big.Int).ModInverse(u2, P),
+		u3Inv: new(big.Int).ModInverse(u3, P),
+	}
+}
+
+// squareRoot returns a square root of value modulo the odd prime P, or nil
+// when value is a non-residue (ModularSquareRoot assumes one exists).
+func squareRoot(value *big.Int, P *big.Int) *big.Int {
+	if value.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	halfP := new(big.Int).Rsh(P, 1)
+	if new(big.Int).Exp(value, halfP, P).Cmp(one) != 0 {
+		return nil
+	}
+	return ModularSquareRoot(value, P)
+}
+
+// forward maps an affine point of the original curve onto the A = -3 curve
+func (obj *isomorphism) forward(p point.Point, P *big.Int) point.Point {
+	x := new(big.Int).Mul(p.X, obj.u2)
+	x.Mod(x, P)
+	y := new(big.Int).Mul(p.Y, obj.u3)
+	y.Mod(y, P)
+	return point.Point{X: x, Y: y}
+}
+
+// backward maps an affine point of the A = -3 curve back to the original curve
+func (obj *isomorphism) backward(p point.Point, P *big.Int) point.Point {
+	x := new(big.Int).Mul(p.X, obj.u2Inv)
+	x.Mod(x, P)
+	y := new(big.Int).Mul(p.Y, obj.u3Inv)
+	y.Mod(y, P)
+	return point.Point{X: x, Y: y}
+}
@@ -11,13 +11,20 @@ import (
 // big.Int's Cmp does not mutate its operand, so sharing is safe.
 var one = big.NewInt(1)
 
-// GeneratorCache holds the precomputed affine table [G, 2G, 4G, ...,
-// 2^nBitLength * G] used by the NAF fixed-base scalar multiplication. It
+// GeneratorCache holds the precomputed affine comb table of G used by the
+// fixed-base scalar multiplication: 2^w entries j*2^(w*i)*G per w-bit window i. It
 // is populated lazily on the first call to MultiplyGenerator and shared
 // across copies of CurveFp via pointer.
 type GeneratorCache struct {
 	Once  sync.Once
 	Table []point.Point
+
+	// PreparedOnce and Prepared cache the PreparedPoint of G used to verify
+	// signatures against prepared public keys
+	PreparedOnce sync.Once
+	Prepared     PreparedPoint
+
+	words []big.Word
 }
 
 // curveMode carries precomputed per-call curve parameters used by the
@@ -28,6 +35,9 @@ type curveMode struct {
 	P       *big.Int
 	aIsZero bool
 	aIsMin3 bool // true when A ≡ -3 (mod P), i.e. A == P - 3
+	// iso is set when the arithmetic runs on an isomorphic A = -3 curve;
+	// toJacobian and fromJacobian then map points onto it and back.
+	iso *isomorphism
 }
 
 func newCurveMode(A *big.Int, P *big.Int) curveMode {
@@ -42,6 +52,12 @@ func newCurveMode(A *big.Int, P *big.Int) curveMode {
 	aPlus3 := new(big.Int).Add(A, big.NewInt(3))
 	if aPlus3.Cmp(P) == 0 {
 		m.aIsMin3 = true
+		return m
+	}
+	if iso := isomorphismFor(A, P); iso != nil {
+		m.A = new(big.Int).Sub(P, big.NewInt(3))
+		m.aIsMin3 = true
+		m.iso = iso
 	}
 	return m
 }
@@ -116,14 +132,13 @@ func ModularSquareRoot(value *big.Int, prime *big.Int) *big.Int {
 // A: Coefficient of the first-order term
 // P: Prime number in the module
 func Multiply(p point.Point, n *big.Int, N *big.Int, A *big.Int, P *big.Int) point.Point {
-	mode := newCurveMode(A, P)
-	return fromJacobian(jacobianMultiply(toJacobian(p), n, N, mode), P)
+	return ladderMultiply(p, n, N, newCurveMode(A, P))
 }
 
 // Add adds two points in elliptic curves.
 func Add(p point.Point, q point.Point, A *big.Int, P *big.Int) point.Point {
 	mode := newCurveMode(A, P)
-	return fromJacobian(jacobianAdd(toJacobian(p), toJacobian(q), mode), P)
+	return fromJacobian(jacobianAdd(toJacobian(p, mode), toJacobian(q, mode), mode), mode)
 }
 
 // GLVParams holds the GLV endomorphism basis for curves that admit one
@@ -155,10 +170,10 @@ func MultiplyAndAddWithGLV(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.
 	}
 	return fromJacobian(
 		shamirMultiply(
-			toJacobian(p1), n1,
-			toJacobian(p2), n2,
+			toJacobian(p1, mode), n1,
+			toJacobian(p2, mode), n2,
 			N, mode,
-		), P,
+		), mode,
 	)
 }
 
@@ -169,49 +184,33 @@ func MultiplyAndAddWithGLV(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.
 // sums. Halves the loop length versus the plain Shamir path.
 func glvMultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int, N *big.Int, mode curveMode, glv *GLVParams) point.Point {
 	P := mode.P
-	beta := glv.Beta
+	jp1, jp2 := toJacobian(p1, mode), toJacobian(p2, mode)
 
 	n1Mod := new(big.Int).Mod(n1, N)
 	n2Mod := new(big.Int).Mod(n2, N)
 	k1, k2 := glvDecompose(n1Mod, glv, N)
 	k3, k4 := glvDecompose(n2Mod, glv, N)
 
-	// Base points (affine, z=1); phi((x, y)) = (beta*x mod P, y).
-	betaP1x := new(big.Int).Mul(beta, p1.X)
-	betaP1x.Mod(betaP1x, P)
-	betaP2x := new(big.Int).Mul(beta, p2.X)
-	betaP2x.Mod(betaP2x, P)
-
-	bases := [4]point.Point{
-		{X: new(big.Int).Set(p1.X), Y: new(big.Int).Set(p1.Y), Z: big.NewInt(1)},
-		{X: betaP1x, Y: new(big.Int).Set(p1.Y), Z: big.NewInt(1)},
-		{X: new(big.Int).Set(p2.X), Y: new(big.Int).Set(p2.Y), Z: big.NewInt(1)},
-		{X: betaP2x, Y: new(big.Int).Set(p2.Y), Z: big.NewInt(1)},
+	// Base points (z=1 or at infinity); phi((x, y)) = (beta*x mod P, y).
+	phi := func(p point.Jacobian) point.Jacobian {
+		if p.IsAtInfinity() {
+			return p
+		}
+		x := new(big.Int).Mul(glv.Beta, p.X)
+		return point.Jacobian{X: x.Mod(x, P), Y: new(big.Int).Set(p.Y), Z: new(big.Int).Set(p.Z)}
 	}
+	bases := [4]point.Jacobian{jp1, phi(jp1), jp2, phi(jp2)}
 	scalars := [4]*big.Int{k1, k2, k3, k4}
 	for i := 0; i < 4; i++ {
 		if scalars[i].Sign() < 0 {
 			scalars[i] = new(big.Int).Neg(scalars[i])
-			y := bases[i].Y
-			var ny *big.Int
-			if y.Sign() == 0 {
-				ny = big.NewInt(0)
-			} else {
-				ny = new(big.Int).Sub(P, y)
-			}
-			bases[i] = point.Point{
-				X: bases[i].X,
-				Y: ny,
-				Z: bases[i].Z,
-			}
+			bases[i] = jacobianNeg(bases[i], P)
 		}
 	}
 
 	// Precompute table[idx] = sum of bases[i] selected by bits of idx.
-	table := make([]point.Point, 16)
-	for i := range table {
-		table[i] = point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
-	}
+	table := make([]point.Jacobian, 16)
+	table[0] = point.JacobianAtInfinity()
 	for idx := 1; idx < 16; idx++ {
 		low := idx & -idx
 		// log2(low)
@@ -228,7 +227,7 @@ func glvMultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int,
 			maxLen = s.BitLen()
 		}
 	}
-	r := point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
+	r := point.JacobianAtInfinity()
 	for bit := maxLen - 1; bit >= 0; bit-- {
 		r = jacobianDouble(r, mode)
 		idx := int(scalars[0].Bit(bit)) |
@@ -240,7 +239,7 @@ func glvMultiplyAndAdd(p1 point.Point, n1 *big.Int, p2 point.Point, n2 *big.Int,
 		}
 	}
 
-	return fromJacobian(r, P)
+	return fromJacobian(r, mode)
 }
 
 // glvDecompose decomposes k into (k1, k2) with k = k1 + k2*lambda (mod N)
@@ -300,88 +299,163 @@ type MultiplyGeneratorParams struct {
 	Cache      *GeneratorCache
 }
 
-// MultiplyGenerator computes n*G using a precomputed affine table of
-// powers-of-two multiples of G and the width-2 NAF of n. Every non-zero NAF
-// digit triggers one mixed add and zero doublings, trading the ~256
-// doublings of a windowed method for ~86 adds on average -- a large net
-// reduction in field multiplications for 256-bit scalars.
-func MultiplyGenerator(params MultiplyGeneratorParams, n *big.Int) point.Point {
-	if n.Sign() < 0 || n.Cmp(params.N) >= 0 {
+// MultiplyGenerator computes n*G with a fixed-base comb: n is split in windows of
+// _combWidth bits, and each window adds the multiple of G it selects from a
+// precomputed row of affine points. Every row is scanned in full and every window
+// takes one complete addition, zero windows adding the point at infinity, so the
+// sequence of operations doesn't depend on n. A blinded scalar k + m*N, with
+// 0 < m < 2^ScalarBlindingBits, runs unreduced over the extra rows of the table, so k
+// itself never selects an entry; other scalars are reduced mod N first. A nonzero z
+// randomizes the projective coordinates, the sum starting from (0 : z : 0) in place
+// of (0 : 1 : 0).
+func MultiplyGenerator(params MultiplyGeneratorParams, n *big.Int, z ...*big.Int) point.Point {
+	if n.Sign() < 0 || n.BitLen() > params.NBitLength+ScalarBlindingBits {
 		n = new(big.Int).Mod(n, params.N)
 	}
 	if n.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(0)}
+		return point.AtInfinity()
 	}
 
 	mode := newCurveMode(params.A, params.P)
-	table := generatorTable(params, mode)
-	P := params.P
-
-	// Work on a fresh copy of n since we mutate it bit-by-bit.
-	k := new(big.Int).Set(n)
-	r := point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
-	i := 0
-	for k.Sign() > 0 {
-		if k.Bit(0) == 1 {
-			// digit = 2 - (k & 3), yielding +1 or -1.
-			low2 := int(k.Bit(0)) | (int(k.Bit(1)) << 1)
-			digit := 2 - low2
-			if digit == 1 {
-				k.Sub(k, one)
-				r = jacobianAdd(r, table[i], mode)
-			} else {
-				k.Add(k, one)
-				g := table[i]
-				neg := point.Point{
-					X: new(big.Int).Set(g.X),
-					Y: new(big.Int).Sub(P, g.Y),
-					Z: new(big.Int).Set(g.Z),
-				}
-				r = jacobianAdd(r, neg, mode)
-			}
+	cache := generatorTable(params, mode)
+	curve := newCompleteCurve(cache.Table[1], mode)
+	words := len(params.P.Bits())
+	// the extra rows only run for blinded scalars, which are at least N
+	rows := (params.NBitLength + _combWidth - 1) / _combWidth
+	if n.Cmp(params.N) >= 0 {
+		rows = len(cache.Table) / _combRowSize
+	}
+
+	r := projectiveAtInfinity()
+	r.Y = projectiveZ(z, params.P)
+	for i := 0; i < rows; i++ {
+		var window uint64
+		for b := 0; b < _combWidth; b++ {
+			window |= uint64(n.Bit(_combWidth*i+b)) << b
+		}
+		coordinates := make([]big.Word, 2*words)
+		row := cache.words[2*words*_combRowSize*i : 2*words*_combRowSize*(i+1)]
+		for j := 0; j < _combRowSize; j++ {
+			selectWords(big.Word(equalFlag(uint64(j), window)), coordinates, row[2*words*j:2*words*(j+1)])
 		}
-		k.Rsh(k, 1)
-		i++
+		// the zero entry of a row becomes (0 : 1 : 0), the others (x : y : 1)
+		infinity := int64(equalFlag(0, window))
+		entry := projective{
+			X: new(big.Int).SetBits(coordinates[:words]),
+			Y: new(big.Int).SetBits(coordinates[words:]),
+			Z: big.NewInt(1 - infinity),
+		}
+		entry.Y.Add(entry.Y, big.NewInt(infinity))
+		r = curve.Add(r, entry)
 	}
-	return fromJacobian(r, params.P)
+	return curve.toPoint(r)
 }
 
-func generatorTable(params MultiplyGeneratorParams, mode curveMode) []point.Point {
+// ScalarBlindingBits is the size of the random m of the blinded scalars k + m*N that
+// the fixed-base multiplications take without reducing them
+const ScalarBlindingBits = 64
+
+// projectiveZ returns the optional z of the fixed-base multiplications reduced mod P,
+// or 1 without one
+func projectiveZ(z []*big.Int, P *big.Int) *big.Int {
+	if len(z) == 0 {
+		return big.NewInt(1)
+	}
+	value := new(big.Int).Mod(z[0], P)
+	if value.Sign() == 0 {
+		panic("The projective Z should be nonzero modulo P")
+	}
+	return value
+}
+
+// generatorTable fills the comb rows of the cache: entry _combRowSize*i + j is
+// j*2^(_combWidth*i)*G in affine coordinates, with the point at infinity for j = 0,
+// for scalars of up to NBitLength + ScalarBlindingBits bits
+func generatorTable(params MultiplyGeneratorParams, mode curveMode) *GeneratorCache {
 	cache := params.Cache
 	cache.Once.Do(func() {
-		P := params.P
-		// NAF of an nBitLength-bit scalar can be up to nBitLength+1 digits.
-		size := params.NBitLength + 1
-		table := make([]point.Point, 0, size)
-		current := point.Point{
-			X: new(big.Int).Set(params.G.X),
-			Y: new(big.Int).Set(params.G.Y),
-			Z: big.NewInt(1),
-		}
-		table = append(table, current)
-		for j := 0; j < params.NBitLength; j++ {
-			doubled := jacobianDouble(current, mode)
-			if doubled.Y.Sign() == 0 {
-				current = doubled
-			} else {
-				zInv := Inv(doubled.Z, P)
-				zInv2 := new(big.Int).Mul(zInv, zInv)
-				zInv2.Mod(zInv2, P)
-				zInv3 := new(big.Int).Mul(zInv2, zInv)
-				zInv3.Mod(zInv3, P)
-				x := new(big.Int).Mul(doubled.X, zInv2)
-				x.Mod(x, P)
-				y := new(big.Int).Mul(doubled.Y, zInv3)
-				y.Mod(y, P)
-				current = point.Point{X: x, Y: y, Z: big.NewInt(1)}
+		rows := (params.NBitLength + ScalarBlindingBits + _combWidth - 1) / _combWidth
+		multiples := make([]point.Jacobian, 0, rows*(_combRowSize-1))
+		base := toJacobian(params.G, mode)
+		for i := 0; i < rows; i++ {
+			// an affine base keeps the additions of the row on the mixed fast path
+			base = batchAffine([]point.Jacobian{base}, mode.P)[0].ToJacobian()
+			multiple := base
+			multiples = append(multiples, multiple)
+			for j := 2; j < _combRowSize; j++ {
+				multiple = jacobianAdd(multiple, base, mode)
+				multiples = append(multiples, multiple)
 			}
-			table = append(table, current)
+			base = jacobianAdd(multiple, base, mode)
+		}
+
+		affine := batchAffine(multiples, mode.P)
+		table := make([]point.Point, 0, rows*_combRowSize)
+		for i := 0; i < rows; i++ {
+			table = append(table, point.AtInfinity())
+			table = append(table, affine[(_combRowSize-1)*i:(_combRowSize-1)*(i+1)]...)
+		}
+
+		// the same coordinates, zero-padded to the width of P, for the selection scans
+		words := len(mode.P.Bits())
+		cache.words = make([]big.Word, 2*words*len(table))
+		for i, entry := range table {
+			copy(cache.words[2*words*i:], entry.X.Bits())
+			copy(cache.words[2*words*i+words:], entry.Y.Bits())
 		}
 		cache.Table = table
 	})
-	return cache.Table
+	return cache
+}
+
+// batchAffine converts Jacobian points, none at infinity, to affine coordinates with a
+// single inversion (Montgomery's trick)
+func batchAffine(points []point.Jacobian, P *big.Int) []point.Point {
+	products := make([]*big.Int, len(points))
+	accumulator := big.NewInt(1)
+	for i, p := range points {
+		products[i] = accumulator
+		accumulator = new(big.Int).Mul(accumulator, p.Z)
+		accumulator.Mod(accumulator, P)
+	}
+	inverse := Inv(accumulator, P)
+
+	affine := make([]point.Point, len(points))
+	for i := len(points) - 1; i >= 0; i-- {
+		// 1/Z_i = (Z_0*...*Z_(i-1)) / (Z_0*...*Z_i)
+		zInv := new(big.Int).Mul(inverse, products[i])
+		zInv.Mod(zInv, P)
+		inverse.Mul(inverse, points[i].Z).Mod(inverse, P)
+		zInv2 := new(big.Int).Mul(zInv, zInv)
+		zInv2.Mod(zInv2, P)
+		x := new(big.Int).Mul(points[i].X, zInv2)
+		x.Mod(x, P)
+		y := new(big.Int).Mul(points[i].Y, zInv2)
+		y.Mul(y, zInv).Mod(y, P)
+		affine[i] = point.Point{X: x, Y: y}
+	}
+	return affine
 }
 
+// selectWords sets z to a, zero-padded to its length, when flag is 1, and leaves it
+// when flag is 0
+func selectWords(flag big.Word, z []big.Word, a []big.Word) {
+	mask := -flag
+	for i, word := range a {
+		z[i] ^= mask & (z[i] ^ word)
+	}
+	for i := len(a); i < len(z); i++ {
+		z[i] &^= mask
+	}
+}
+
+// _combWidth is the window width of the generator comb, with _combRowSize entries
+// per window
+const (
+	_combWidth   = 5
+	_combRowSize = 1 << _combWidth
+)
+
 // Inv computes modular inverse via the extended Euclidean algorithm
 // (big.Int.ModInverse). Roughly 2-3x faster than Fermat's little theorem
 // for 256-bit operands.
@@ -400,36 +474,37 @@ func Inv(x *big.Int, n *big.Int) *big.Int {
 	return r
 }
 
-// toJacobian converts a point to Jacobian coordinates
-func toJacobian(p point.Point) point.Point {
-	return point.Point{X: new(big.Int).Set(p.X), Y: new(big.Int).Set(p.Y), Z: big.NewInt(1)}
+// toJacobian converts an affine point to Jacobian coordinates, on the isomorphic
+// A = -3 curve when the mode uses one
+func toJacobian(p point.Point, mode curveMode) point.Jacobian {
+	if mode.iso != nil && !p.IsAtInfinity() {
+		p = mode.iso.forward(p, mode.P)
+	}
+	return p.ToJacobian()
 }
 
-// fromJacobian converts a point back from Jacobian coordinates
-func fromJacobian(p point.Point, P *big.Int) point.Point {
-	if p.Y.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(0)}
+// fromJacobian converts a point back to affine coordinates on the original curve
+func fromJacobian(p point.Jacobian, mode curveMode) point.Point {
+	affine := p.ToAffine(mode.P)
+	if mode.iso != nil && !affine.IsAtInfinity() {
+		return mode.iso.backward(affine, mode.P)
 	}
+	return affine
+}
 
-	z := Inv(p.Z, P)
-	z2 := new(big.Int).Mul(z, z)
-	z2.Mod(z2, P)
-	z3 := new(big.Int).Mul(z2, z)
-	z3.Mod(z3, P)
-
-	x := new(big.Int).Mul(p.X, z2)
-	x.Mod(x, P)
-	y := new(big.Int).Mul(p.Y, z3)
-	y.Mod(y, P)
-
-	return point.Point{X: x, Y: y, Z: big.NewInt(0)}
+// jacobianNeg returns -p = (X, -Y, Z)
+func jacobianNeg(p point.Jacobian, P *big.Int) point.Jacobian {
+	y := new(big.Int).Sub(P, p.Y)
+	return point.Jacobian{X: new(big.Int).Set(p.X), Y: y.Mod(y, P), Z: new(big.Int).Set(p.Z)}
 }
 
 // jacobianDouble doubles a point in Jacobian coordinates. Uses curve-specific
-// shortcuts when the coefficient A is 0 (secp256k1) or A ≡ -3 (prime256v1).
-func jacobianDouble(p point.Point, mode curveMode) point.Point {
-	if p.Y.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(0)}
+// shortcuts when the coefficient A is 0 (secp256k1) or A ≡ -3 (prime256v1, and
+// curves such as brainpoolP256r1 that newCurveMode maps onto an A = -3 twist).
+func jacobianDouble(p point.Jacobian, mode curveMode) point.Jacobian {
+	// a point of order 2 has Y = 0, which gives Z = 0 below
+	if p.IsAtInfinity() {
+		return point.JacobianAtInfinity()
 	}
 
 	P := mode.P
@@ -477,17 +552,17 @@ func jacobianDouble(p point.Point, mode curveMode) point.Point {
 	nz := new(big.Int).Mul(big.NewInt(2), py)
 	nz.Mul(nz, pz).Mod(nz, P)
 
-	return point.Point{X: nx, Y: ny, Z: nz}
+	return point.Jacobian{X: nx, Y: ny, Z: nz}
 }
 
 // jacobianAdd adds two points in Jacobian coordinates. When qz == 1 (q is
 // affine) it takes a mixed-add fast path that saves four field multiplications
 // per call (no qz^2, simplified U1, S1, and nz).
-func jacobianAdd(p point.Point, q point.Point, mode curveMode) point.Point {
-	if p.Y.Sign() == 0 {
+func jacobianAdd(p point.Jacobian, q point.Jacobian, mode curveMode) point.Jacobian {
+	if p.IsAtInfinity() {
 		return q
 	}
-	if q.Y.Sign() == 0 {
+	if q.IsAtInfinity() {
 		return p
 	}
 
@@ -521,7 +596,7 @@ func jacobianAdd(p point.Point, q point.Point, mode curveMode) point.Point {
 
 	if U1.Cmp(U2) == 0 {
 		if S1.Cmp(S2) != 0 {
-			return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
+			return point.JacobianAtInfinity()
 		}
 		return jacobianDouble(p, mode)
 	}
@@ -551,46 +626,51 @@ func jacobianAdd(p point.Point, q point.Point, mode curveMode) point.Point {
 		nz.Mul(nz, qz).Mod(nz, P)
 	}
 
-	return point.Point{X: nx, Y: ny, Z: nz}
+	return point.Jacobian{X: nx, Y: ny, Z: nz}
 }
 
-// jacobianMultiply multiplies point and scalar using Montgomery ladder
-// for constant-time execution.
-func jacobianMultiply(p point.Point, n *big.Int, N *big.Int, mode curveMode) point.Point {
-	if p.Y.Sign() == 0 || n.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
-	}
-
+// ladderMultiply multiplies point and scalar with a Montgomery ladder over the
+// complete formulas: every bit up to the length of N takes one addition and one
+// doubling, with the operands swapped by masks instead of branches
+func ladderMultiply(p point.Point, n *big.Int, N *big.Int, mode curveMode) point.Point {
 	if n.Sign() < 0 || n.Cmp(N) >= 0 {
 		n = new(big.Int).Mod(n, N)
 	}
-
-	if n.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
+	if p.IsAtInfinity() || n.Sign() == 0 {
+		return point.AtInfinity()
 	}
-
-	// Montgomery ladder: always performs one add and one double per bit
-	r0 := point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
-	r1 := point.Point{X: new(big.Int).Set(p.X), Y: new(big.Int).Set(p.Y), Z: new(big.Int).Set(p.Z)}
-
-	for i := n.BitLen() - 1; i >= 0; i-- {
-		if n.Bit(i) == 0 {
-			r1 = jacobianAdd(r0, r1, mode)
-			r0 = jacobianDouble(r0, mode)
-		} else {
-			r0 = jacobianAdd(r0, r1, mode)
-			r1 = jacobianDouble(r1, mode)
+	if new(big.Int).Mod(p.Y, mode.P).Sign() == 0 {
+		// p has order 2, the one case where r1 - r0 = p makes the formulas exceptional
+		if n.Bit(0) == 0 {
+			return point.AtInfinity()
 		}
+		return p
 	}
 
-	return r0
+	x, y := new(big.Int).Mod(p.X, mode.P), new(big.Int).Mod(p.Y, mode.P)
+	if mode.iso != nil {
+		p = mode.iso.forward(point.Point{X: x, Y: y}, mode.P)
+		x, y = p.X, p.Y
+	}
+	curve := newCompleteCurve(point.Point{X: x, Y: y}, mode)
+	words := len(mode.P.Bits())
+	r0 := projectiveAtInfinity()
+	r1 := projective{X: x, Y: y, Z: big.NewInt(1)}
+	for i := N.BitLen() - 1; i >= 0; i-- {
+		bit := big.Word(n.Bit(i))
+		r0, r1 = selectProjective(bit, r1, r0, words), selectProjective(bit, r0, r1, words)
+		r1 = curve.Add(r0, r1)
+		r0 = curve.Double(r0)
+		r0, r1 = selectProjective(bit, r1, r0, words), selectProjective(bit, r0, r1, words)
+	}
+	return curve.toPoint(r0)
 }
 
 // shamirMultiply computes n1*p1 + n2*p2 using Shamir's trick with Joint
 // Sparse Form (Solinas 2001). JSF picks signed digits in {-1, 0, 1} so at
 // most ~l/2 digit pairs are non-zero, versus ~3l/4 for the raw binary form.
 // Not constant-time -- use only with public scalars (e.g. verification).
-func shamirMultiply(jp1 point.Point, n1 *big.Int, jp2 point.Point, n2 *big.Int, N *big.Int, mode curveMode) point.Point {
+func shamirMultiply(jp1 point.Jacobian, n1 *big.Int, jp2 point.Jacobian, n2 *big.Int, N *big.Int, mode curveMode) point.Jacobian {
 	if n1.Sign() < 0 || n1.Cmp(N) >= 0 {
 		n1 = new(big.Int).Mod(n1, N)
 	}
@@ -599,22 +679,12 @@ func shamirMultiply(jp1 point.Point, n1 *big.Int, jp2 point.Point, n2 *big.Int,
 	}
 
 	if n1.Sign() == 0 && n2.Sign() == 0 {
-		return point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
+		return point.JacobianAtInfinity()
 	}
 
 	P := mode.P
-	neg := func(pt point.Point) point.Point {
-		var ny *big.Int
-		if pt.Y.Sign() == 0 {
-			ny = big.NewInt(0)
-		} else {
-			ny = new(big.Int).Sub(P, pt.Y)
-		}
-		return point.Point{
-			X: new(big.Int).Set(pt.X),
-			Y: ny,
-			Z: new(big.Int).Set(pt.Z),
-		}
+	neg := func(pt point.Jacobian) point.Jacobian {
+		return jacobianNeg(pt, P)
 	}
 
 	jp1p2 := jacobianAdd(jp1, jp2, mode)
@@ -625,14 +695,14 @@ func shamirMultiply(jp1 point.Point, n1 *big.Int, jp2 point.Point, n2 *big.Int,
 	negJp1mp2 := neg(jp1mp2)
 
 	digits := jsfDigits(n1, n2)
-	r := point.Point{X: big.NewInt(0), Y: big.NewInt(0), Z: big.NewInt(1)}
+	r := point.JacobianAtInfinity()
 	for _, d := range digits {
 		r = jacobianDouble(r, mode)
 		u0, u1 := d[0], d[1]
 		if u0 == 0 && u1 == 0 {
 			continue
 		}
-		var addend point.Point
+		var addend point.Jacobian
 		switch {
 		case u0 == 1 && u1 == 0:
 			addend = jp1
@@ -0,0 +1,192 @@
+package math
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// MultiScalarMultParams bundles the curve parameters of MultiScalarMult, like
+// MultiplyGeneratorParams. GLV is nil on curves without an endomorphism.
+type MultiScalarMultParams struct {
+	A   *big.Int
+	P   *big.Int
+	N   *big.Int
+	GLV *GLVParams
+}
+
+// MultiScalarMult computes scalars[0]*points[0] + ... + scalars[n-1]*points[n-1], or the
+// point at infinity for no points. Every scalar is split in two with the GLV
+// endomorphism when the curve has one; up to _pippengerThreshold terms are then summed
+// with Straus' method over width-5 wNAFs, and more with Pippenger's bucket method.
+// Not constant-time -- use only with public scalars (e.g. batch verification).
+func MultiScalarMult(points []point.Point, scalars []*big.Int, params MultiScalarMultParams) point.Point {
+	if len(points) != len(scalars) {
+		panic(fmt.Sprintf("The number of points (%v) and scalars (%v) should match", len(points), len(scalars)))
+	}
+	mode := newCurveMode(params.A, params.P)
+
+	var bases []point.Jacobian
+	var ks []*big.Int
+	for i, p := range points {
+		k := new(big.Int).Mod(scalars[i], params.N)
+		if p.IsAtInfinity() || k.Sign() == 0 {
+			continue
+		}
+		base := toJacobian(p, mode)
+		if params.GLV == nil {
+			bases = append(bases, base)
+			ks = append(ks, k)
+			continue
+		}
+		// phi((x, y)) = (beta*x, y)
+		k1, k2 := glvDecompose(k, params.GLV, params.N)
+		x := new(big.Int).Mul(params.GLV.Beta, base.X)
+		image := point.Jacobian{X: x.Mod(x, mode.P), Y: new(big.Int).Set(base.Y), Z: big.NewInt(1)}
+		bases = append(bases, base, image)
+		ks = append(ks, k1, k2)
+	}
+	// the signs of the scalars go to their points
+	for i, k := range ks {
+		if k.Sign() < 0 {
+			ks[i] = new(big.Int).Neg(k)
+			bases[i] = jacobianNeg(bases[i], mode.P)
+		}
+	}
+
+	if len(bases) <= _pippengerThreshold {
+		return fromJacobian(strausMultiply(bases, ks, mode), mode)
+	}
+	return fromJacobian(pippengerMultiply(bases, ks, mode), mode)
+}
+
+// strausMultiply sums k_i*p_i for affine p_i and k_i >= 0 with one chain of doublings
+// and the odd multiples p_i, 3p_i, ..., 15p_i of every point, converted to affine
+// coordinates with a single inversion
+func strausMultiply(bases []point.Jacobian, scalars []*big.Int, mode curveMode) point.Jacobian {
+	multiples := make([]point.Jacobian, 0, len(bases)*_wnafTableSize)
+	finite := true
+	for _, base := range bases {
+		double := jacobianDouble(base, mode)
+		multiple := base
+		multiples = append(multiples, multiple)
+		for j := 1; j < _wnafTableSize; j++ {
+			multiple = jacobianAdd(multiple, double, mode)
+			multiples = append(multiples, multiple)
+			finite = finite && !multiple.IsAtInfinity()
+		}
+	}
+	if finite {
+		// points of small order keep their Jacobian tables
+		for i, multiple := range batchAffine(multiples, mode.P) {
+			multiples[i] = multiple.ToJacobian()
+		}
+	}
+
+	digits := make([][]int8, len(scalars))
+	for i, k := range scalars {
+		digits[i] = wnaf(k, _wnafWidth)
+	}
+	r := point.JacobianAtInfinity()
+	for bit := maxLength(digits) - 1; bit >= 0; bit-- {
+		r = jacobianDouble(r, mode)
+		for i := range digits {
+			if bit >= len(digits[i]) {
+				continue
+			}
+			table := multiples[_wnafTableSize*i : _wnafTableSize*(i+1)]
+			if digit := digits[i][bit]; digit > 0 {
+				r = jacobianAdd(r, table[digit/2], mode)
+			} else if digit < 0 {
+				r = jacobianAdd(r, jacobianNeg(table[-digit/2], mode.P), mode)
+			}
+		}
+	}
+	return r
+}
+
+// pippengerMultiply sums k_i*p_i for affine p_i and k_i >= 0 with Pippenger's bucket
+// method: the scalars are recoded in signed base-2^c digits, and every window adds each
+// point to the bucket of its digit, then sums the buckets weighted by their digits
+func pippengerMultiply(bases []point.Jacobian, scalars []*big.Int, mode curveMode) point.Jacobian {
+	bitLength := 0
+	for _, k := range scalars {
+		if k.BitLen() > bitLength {
+			bitLength = k.BitLen()
+		}
+	}
+	c := pippengerWindow(len(bases), bitLength)
+	windows := (bitLength+c-1)/c + 1
+	digits := make([][]int, len(scalars))
+	for i, k := range scalars {
+		digits[i] = signedDigits(k, c, windows)
+	}
+	negatives := make([]point.Jacobian, len(bases))
+	for i, base := range bases {
+		negatives[i] = jacobianNeg(base, mode.P)
+	}
+
+	r := point.JacobianAtInfinity()
+	buckets := make([]point.Jacobian, 1<<(c-1))
+	for w := windows - 1; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			r = jacobianDouble(r, mode)
+		}
+		for j := range buckets {
+			buckets[j] = point.JacobianAtInfinity()
+		}
+		for i := range bases {
+			if digit := digits[i][w]; digit > 0 {
+				buckets[digit-1] = jacobianAdd(buckets[digit-1], bases[i], mode)
+			} else if digit < 0 {
+				buckets[-digit-1] = jacobianAdd(buckets[-digit-1], negatives[i], mode)
+			}
+		}
+		// sum of j*buckets[j-1], as the running sums of the buckets from the top
+		running, sum := point.JacobianAtInfinity(), point.JacobianAtInfinity()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			running = jacobianAdd(running, buckets[j], mode)
+			sum = jacobianAdd(sum, running, mode)
+		}
+		r = jacobianAdd(r, sum, mode)
+	}
+	return r
+}
+
+// pippengerWindow returns the window c that minimizes the additions of
+// pippengerMultiply: n points and 2^c bucket sums for each of its windows
+func pippengerWindow(n int, bitLength int) int {
+	best, bestCost := 2, -1
+	for c := 2; c <= 16; c++ {
+		cost := ((bitLength+c-1)/c + 1) * (n + 1<<c)
+		if bestCost < 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// signedDigits recodes k >= 0 in the given number of base-2^c digits in
+// [-2^(c-1), 2^(c-1)), least significant first
+func signedDigits(k *big.Int, c int, windows int) []int {
+	digits := make([]int, windows)
+	carry := 0
+	for i := range digits {
+		digit := carry
+		for j := 0; j < c; j++ {
+			digit += int(k.Bit(c*i+j)) << j
+		}
+		carry = 0
+		if digit >= 1<<(c-1) {
+			digit -= 1 << c
+			carry = 1
+		}
+		digits[i] = digit
+	}
+	return digits
+}
+
+// _pippengerThreshold is the number of terms, after the GLV split, above which
+// MultiScalarMult switches from Straus' method to Pippenger's
+const _pippengerThreshold = 64
@@ -0,0 +1,65 @@
+package math
+
+import (
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// _p256Field is p = 2^256 - 2^224 + 2^192 + 2^96 - 1, in Montgomery form
+var _p256Field = newField256(
+	hexInt("ffffffff00000001000000000000000000000000ffffffffffffffffffffffff"),
+	_p256Chain,
+)
+
+// NewP256Backend returns the Backend of P-256 (prime256v1), given its generator G and
+// order N
+func NewP256Backend(G point.Point, N *big.Int) Backend {
+	return newBackend256(_p256Field, true, G, N, nil)
+}
+
+// invP256 computes a^(p - 2) in 255 squarings and 12 multiplications, through the
+// addition chain
+//
+//	_10     = 2*1
+//	_11     = 1 + _10
+//	_110    = 2*_11
+//	_111    = 1 + _110
+//	_111000 = _111 << 3
+//	_111111 = _111 + _111000
+//	x12     = _111111 << 6 + _111111
+//	x15     = x12 << 3 + _111
+//	x16     = 2*x15 + 1
+//	x32     = x16 << 16 + x16
+//	i53     = x32 << 15
+//	x47     = x15 + i53
+//	i263    = ((i53 << 17 + 1) << 143 + x47) << 47
+//	return    (x47 + i263) << 2 + 1
+func (obj *field256) invP256(z *element256, a *element256) {
+	var x3, x6, x12, x15, x16, x32, i53, x47, t element256
+	obj.square(&x3, a)
+	obj.mul(&x3, &x3, a)
+	obj.square(&x3, &x3)
+	obj.mul(&x3, &x3, a)
+	obj.squareN(&x6, &x3, 3)
+	obj.mul(&x6, &x6, &x3)
+	obj.squareN(&x12, &x6, 6)
+	obj.mul(&x12, &x12, &x6)
+	obj.squareN(&x15, &x12, 3)
+	obj.mul(&x15, &x15, &x3)
+	obj.square(&x16, &x15)
+	obj.mul(&x16, &x16, a)
+	obj.squareN(&x32, &x16, 16)
+	obj.mul(&x32, &x32, &x16)
+	obj.squareN(&i53, &x32, 15)
+	obj.mul(&x47, &x15, &i53)
+
+	obj.squareN(&t, &i53, 17)
+	obj.mul(&t, &t, a)
+	obj.squareN(&t, &t, 143)
+	obj.mul(&t, &t, &x47)
+	obj.squareN(&t, &t, 47)
+	obj.mul(&t, &x47, &t)
+	obj.squareN(&t, &t, 2)
+	obj.mul(z, &t, a)
+}
@@ -0,0 +1,139 @@
+package math
+
+import (
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// PreparedPoint is a point with precomputed multiples, for multiplying it by many
+// public scalars, e.g. to verify signatures against the same public key. Prepare and
+// Backend.Prepare make them.
+type PreparedPoint interface {
+	Point() point.Point
+}
+
+// preparedPoint holds the affine odd multiples P, 3P, ..., 63P of a point, on the
+// isomorphic curve when curveMode uses one, and their images phi when the curve
+// has a GLV endomorphism. tables is nil at infinity.
+type preparedPoint struct {
+	point  point.Point
+	glv    *GLVParams
+	tables [][]point.Point
+}
+
+func (obj *preparedPoint) Point() point.Point {
+	return obj.point
+}
+
+// Prepare precomputes the width-7 wNAF table of p, and of phi(p) when glv isn't nil,
+// for MultiplyAndAddPrepared
+func Prepare(p point.Point, A *big.Int, P *big.Int, glv *GLVParams) PreparedPoint {
+	prepared := &preparedPoint{point: p, glv: glv}
+	if p.IsAtInfinity() {
+		return prepared
+	}
+
+	mode := newCurveMode(A, P)
+	base := toJacobian(p, mode)
+	double := jacobianDouble(base, mode)
+	multiples := make([]point.Jacobian, _preparedTableSize)
+	multiples[0] = base
+	for j := 1; j < len(multiples); j++ {
+		multiples[j] = jacobianAdd(multiples[j-1], double, mode)
+		if multiples[j].IsAtInfinity() {
+			// a point of small order, left to MultiplyAndAdd
+			return prepared
+		}
+	}
+	table := batchAffine(multiples, P)
+	prepared.tables = [][]point.Point{table}
+
+	if glv != nil {
+		// phi((x, y)) = (beta*x, y)
+		images := make([]point.Point, len(table))
+		for j, multiple := range table {
+			x := new(big.Int).Mul(glv.Beta, multiple.X)
+			images[j] = point.Point{X: x.Mod(x, P), Y: multiple.Y}
+		}
+		prepared.tables = append(prepared.tables, images)
+	}
+	return prepared
+}
+
+// MultiplyAndAddPrepared computes n1*p1 + n2*p2 with Straus' method over width-7
+// wNAFs of the scalars, split by the GLV endomorphism when the points were prepared
+// with one. Not constant-time -- use only with public scalars (e.g. verification).
+func MultiplyAndAddPrepared(p1 PreparedPoint, n1 *big.Int, p2 PreparedPoint, n2 *big.Int, N *big.Int, A *big.Int, P *big.Int) point.Point {
+	mode := newCurveMode(A, P)
+	var tables [][]point.Point
+	var digits [][]int8
+	for i, p := range []PreparedPoint{p1, p2} {
+		prepared, ok := p.(*preparedPoint)
+		if !ok {
+			prepared = Prepare(p.Point(), A, P, nil).(*preparedPoint)
+		}
+		if prepared.tables == nil {
+			if !prepared.point.IsAtInfinity() {
+				return MultiplyAndAdd(p1.Point(), n1, p2.Point(), n2, N, A, P)
+			}
+			continue
+		}
+
+		scalar := new(big.Int).Mod([]*big.Int{n1, n2}[i], N)
+		scalars := []*big.Int{scalar}
+		if prepared.glv != nil {
+			k1, k2 := glvDecompose(scalar, prepared.glv, N)
+			scalars = []*big.Int{k1, k2}
+		}
+		for j, k := range scalars {
+			tables = append(tables, prepared.tables[j])
+			digits = append(digits, signedWnaf(k, _preparedWnafWidth))
+		}
+	}
+
+	r := point.JacobianAtInfinity()
+	for bit := maxLength(digits) - 1; bit >= 0; bit-- {
+		r = jacobianDouble(r, mode)
+		for i := range digits {
+			if bit >= len(digits[i]) {
+				continue
+			}
+			if digit := digits[i][bit]; digit > 0 {
+				r = jacobianAdd(r, tables[i][digit/2].ToJacobian(), mode)
+			} else if digit < 0 {
+				r = jacobianAdd(r, jacobianNeg(tables[i][-digit/2].ToJacobian(), P), mode)
+			}
+		}
+	}
+	return fromJacobian(r, mode)
+}
+
+// signedWnaf is wnaf for any k, with the digits of |k| negated when k < 0
+func signedWnaf(k *big.Int, w int) []int8 {
+	if k.Sign() >= 0 {
+		return wnaf(k, w)
+	}
+	digits := wnaf(new(big.Int).Neg(k), w)
+	for i := range digits {
+		digits[i] = -digits[i]
+	}
+	return digits
+}
+
+func maxLength(digits [][]int8) int {
+	length := 0
+	for _, d := range digits {
+		if len(d) > length {
+			length = len(d)
+		}
+	}
+	return length
+}
+
+// _preparedWnafWidth is the wNAF width of prepared points, whose tables hold
+// _preparedTableSize odd multiples
+const (
+	_preparedWnafWidth = 7
+	_preparedTableSize = 1 << (_preparedWnafWidth - 2)
+)
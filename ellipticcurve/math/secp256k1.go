@@ -0,0 +1,60 @@
+package math
+
+import (
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+)
+
+// _secp256k1Field is p = 2^256 - 2^32 - 977, reduced through 2^256 = 2^32 + 977
+var _secp256k1Field = newField256(
+	hexInt("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"),
+	_secp256k1Chain,
+)
+
+// NewSecp256k1Backend returns the Backend of secp256k1, given its generator G, order N
+// and GLV endomorphism
+func NewSecp256k1Backend(G point.Point, N *big.Int, glv *GLVParams) Backend {
+	return newBackend256(_secp256k1Field, false, G, N, glv)
+}
+
+// invSecp256k1 computes a^(p - 2) in 255 squarings and 15 multiplications
+func (obj *field256) invSecp256k1(z *element256, a *element256) {
+	var x2, x3, x6, x9, x11, x22, x44, x88, x176, x220, x223, t element256
+	obj.square(&x2, a)
+	obj.mul(&x2, &x2, a)
+	obj.square(&x3, &x2)
+	obj.mul(&x3, &x3, a)
+	obj.squareN(&x6, &x3, 3)
+	obj.mul(&x6, &x6, &x3)
+	obj.squareN(&x9, &x6, 3)
+	obj.mul(&x9, &x9, &x3)
+	obj.squareN(&x11, &x9, 2)
+	obj.mul(&x11, &x11, &x2)
+	obj.squareN(&x22, &x11, 11)
+	obj.mul(&x22, &x22, &x11)
+	obj.squareN(&x44, &x22, 22)
+	obj.mul(&x44, &x44, &x22)
+	obj.squareN(&x88, &x44, 44)
+	obj.mul(&x88, &x88, &x44)
+	obj.squareN(&x176, &x88, 88)
+	obj.mul(&x176, &x176, &x88)
+	obj.squareN(&x220, &x176, 44)
+	obj.mul(&x220, &x220, &x44)
+	obj.squareN(&x223, &x220, 3)
+	obj.mul(&x223, &x223, &x3)
+
+	obj.squareN(&t, &x223, 23)
+	obj.mul(&t, &t, &x22)
+	obj.squareN(&t, &t, 5)
+	obj.mul(&t, &t, a)
+	obj.squareN(&t, &t, 3)
+	obj.mul(&t, &t, &x2)
+	obj.squareN(&t, &t, 2)
+	obj.mul(z, &t, a)
+}
+
+func hexInt(s string) *big.Int {
+	value, _ := new(big.Int).SetString(s, 16)
+	return value
+}
@@ -0,0 +1,136 @@
+//
+// Montgomery Curve Equation
+//
+// v^2 = u^3 + A*u^2 + u (mod P)
+//
+// https://datatracker.ietf.org/doc/html/rfc7748
+//
+
+package montgomery
+
+import (
+	"fmt"
+	"math/big"
+)
+
+type Curve struct {
+	Name   string
+	P      *big.Int
+	A24    *big.Int // (A - 2) / 4, the constant of the ladder's doubling
+	U      *big.Int // u-coordinate of the base point
+	Length int      // byte length of scalars and u-coordinates
+	Oid    []int64
+
+	field *field
+	clamp func(scalar []byte)
+}
+
+func New(name string, PHex string, A24Hex string, UHex string, oid []int64, clamp func(scalar []byte)) Curve {
+	P, _ := new(big.Int).SetString(PHex, 0)
+	A24, _ := new(big.Int).SetString(A24Hex, 0)
+	U, _ := new(big.Int).SetString(UHex, 0)
+
+	return Curve{
+		Name:   name,
+		P:      P,
+		A24:    A24,
+		U:      U,
+		Length: (P.BitLen() + 7) / 8,
+		Oid:    oid,
+		field:  newField(P),
+		clamp:  clamp,
+	}
+}
+
+// Curve25519 is the curve of the X25519 function (RFC 7748 §4.1)
+var Curve25519 = New(
+	"X25519",
+	"0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed",
+	"0x01db41",
+	"0x09",
+	[]int64{1, 3, 101, 110},
+	func(scalar []byte) {
+		scalar[0] &= 248
+		scalar[31] &= 127
+		scalar[31] |= 64
+	},
+)
+
+// Curve448 is the curve of the X448 function (RFC 7748 §4.2)
+var Curve448 = New(
+	"X448",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffeffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	"0x98a9",
+	"0x05",
+	[]int64{1, 3, 101, 111},
+	func(scalar []byte) {
+		scalar[0] &= 252
+		scalar[55] |= 128
+	},
+)
+
+// ScalarMult computes the X25519 or X448 function of RFC 7748 §5: the clamped scalar times
+// the point with u-coordinate u, both little-endian, in time independent of their values
+func (obj Curve) ScalarMult(scalar []byte, u []byte) []byte {
+	if len(scalar) != obj.Length || len(u) != obj.Length {
+		panic(fmt.Sprintf(
+			"%v takes %v-byte scalars and u-coordinates, but %v and %v bytes were given",
+			obj.Name,
+			obj.Length,
+			len(scalar),
+			len(u),
+		))
+	}
+	k := append([]byte{}, scalar...)
+	obj.clamp(k)
+
+	// u-coordinates ignore the unused top bits and may be non-canonical, up to 2^bits - 1 < 2P
+	uCopy := append([]byte{}, u...)
+	if unused := 8*obj.Length - obj.P.BitLen(); unused > 0 {
+		uCopy[obj.Length-1] &= byte(0xff) >> unused
+	}
+	return obj.ladder(k, uCopy)
+}
+
+// ScalarBaseMult computes ScalarMult with the base point, i.e. the public key of scalar
+func (obj Curve) ScalarBaseMult(scalar []byte) []byte {
+	base := obj.U.FillBytes(make([]byte, obj.Length))
+	return obj.ScalarMult(scalar, reversed(base))
+}
+
+// ladder is the Montgomery ladder of RFC 7748 §5, swapping (x2, z2) and (x3, z3) with
+// masks instead of branches
+func (obj Curve) ladder(k []byte, u []byte) []byte {
+	f := obj.field
+	x1 := f.fromBytes(u)
+	a24 := f.fromBytes(reversed(obj.A24.FillBytes(make([]byte, obj.Length))))
+	x2, z2 := f.one, element{}
+	x3, z3 := x1, f.one
+
+	swap := uint64(0)
+	for t := obj.P.BitLen() - 1; t >= 0; t-- {
+		bit := uint64(k[t/8]>>(t%8)) & 1
+		swap ^= bit
+		f.cswap(&x2, &x3, swap)
+		f.cswap(&z2, &z3, swap)
+		swap = bit
+
+		a := f.add(x2, z2)
+		aa := f.square(a)
+		b := f.sub(x2, z2)
+		bb := f.square(b)
+		e := f.sub(aa, bb)
+		c := f.add(x3, z3)
+		d := f.sub(x3, z3)
+		da := f.mul(d, a)
+		cb := f.mul(c, b)
+		x3 = f.square(f.add(da, cb))
+		z3 = f.mul(x1, f.square(f.sub(da, cb)))
+		x2 = f.mul(aa, bb)
+		z2 = f.mul(e, f.add(aa, f.mul(a24, e)))
+	}
+	f.cswap(&x2, &x3, swap)
+	f.cswap(&z2, &z3, swap)
+
+	return f.toBytes(f.mul(x2, f.inv(z2)), obj.Length)
+}
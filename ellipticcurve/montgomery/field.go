@@ -0,0 +1,182 @@
+package montgomery
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// element is a field element as little-endian 64-bit limbs, in Montgomery form
+// (x*R mod P, R = 2^(64n)); only the first n limbs of the field are used
+type element [_maxLimbs]uint64
+
+// field does constant-time arithmetic modulo an odd prime P: no branch or memory access
+// depends on the values, only on P, so the ladder doesn't leak the scalar through timing
+type field struct {
+	n        int
+	p        element
+	pInv     uint64   // -P^-1 mod 2^64
+	rr       element  // R^2 mod P, to convert into Montgomery form
+	one      element  // R mod P, 1 in Montgomery form
+	exponent *big.Int // P - 2, the public exponent of the inversion
+}
+
+func newField(P *big.Int) *field {
+	n := (P.BitLen() + 63) / 64
+	obj := &field{n: n, exponent: new(big.Int).Sub(P, big.NewInt(2))}
+	obj.p = obj.limbs(P)
+
+	// Newton's iteration doubles the correct low bits of P^-1 mod 2^64 at every step
+	inv := uint64(1)
+	for i := 0; i < 6; i++ {
+		inv *= 2 - obj.p[0]*inv
+	}
+	obj.pInv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), uint(64*n))
+	obj.one = obj.limbs(new(big.Int).Mod(r, P))
+	obj.rr = obj.limbs(new(big.Int).Mod(new(big.Int).Mul(r, r), P))
+	return obj
+}
+
+// limbs splits a value below 2^(64n) into limbs, for setup only
+func (obj *field) limbs(value *big.Int) element {
+	return obj.load(reversed(value.FillBytes(make([]byte, 8*obj.n))))
+}
+
+// load reads up to 8n little-endian bytes into limbs, without reducing
+func (obj *field) load(data []byte) element {
+	var x element
+	for i, b := range data {
+		x[i/8] |= uint64(b) << (8 * (i % 8))
+	}
+	return x
+}
+
+// fromBytes reads a little-endian value below 2P into Montgomery form
+func (obj *field) fromBytes(data []byte) element {
+	x := obj.reduceOnce(obj.load(data), 0)
+	return obj.mul(x, obj.rr)
+}
+
+// toBytes returns the canonical little-endian encoding of x in length bytes
+func (obj *field) toBytes(x element, length int) []byte {
+	var one element
+	one[0] = 1
+	x = obj.mul(x, one)
+	encoded := make([]byte, length)
+	for i := range encoded {
+		encoded[i] = byte(x[i/8] >> (8 * (i % 8)))
+	}
+	return encoded
+}
+
+func (obj *field) add(a element, b element) element {
+	var sum element
+	var carry uint64
+	for i := 0; i < obj.n; i++ {
+		sum[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	return obj.reduceOnce(sum, carry)
+}
+
+func (obj *field) sub(a element, b element) element {
+	var difference element
+	var borrow uint64
+	for i := 0; i < obj.n; i++ {
+		difference[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	// add P back when the subtraction wrapped around
+	mask := -borrow
+	var carry uint64
+	for i := 0; i < obj.n; i++ {
+		difference[i], carry = bits.Add64(difference[i], obj.p[i]&mask, carry)
+	}
+	return difference
+}
+
+// mul returns a*b*R^-1 mod P by coarsely integrated operand scanning (CIOS)
+func (obj *field) mul(a element, b element) element {
+	n := obj.n
+	var t [_maxLimbs + 2]uint64
+	for i := 0; i < n; i++ {
+		var c, carry uint64
+		for j := 0; j < n; j++ {
+			c, t[j] = mulAdd(a[j], b[i], t[j], c)
+		}
+		t[n], carry = bits.Add64(t[n], c, 0)
+		t[n+1] = carry
+
+		m := t[0] * obj.pInv
+		c, _ = mulAdd(m, obj.p[0], t[0], 0)
+		for j := 1; j < n; j++ {
+			c, t[j-1] = mulAdd(m, obj.p[j], t[j], c)
+		}
+		t[n-1], carry = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + carry
+	}
+	var result element
+	copy(result[:n], t[:n])
+	return obj.reduceOnce(result, t[n])
+}
+
+func (obj *field) square(a element) element {
+	return obj.mul(a, a)
+}
+
+// inv returns a^(P-2); the exponent is public, so branching on its bits is safe
+func (obj *field) inv(a element) element {
+	result := obj.one
+	for i := obj.exponent.BitLen() - 1; i >= 0; i-- {
+		result = obj.square(result)
+		if obj.exponent.Bit(i) == 1 {
+			result = obj.mul(result, a)
+		}
+	}
+	return result
+}
+
+// reduceOnce subtracts P from the value x + hi*2^(64n) when it's at least P,
+// which brings any value below 2P into [0, P)
+func (obj *field) reduceOnce(x element, hi uint64) element {
+	var reduced element
+	var borrow uint64
+	for i := 0; i < obj.n; i++ {
+		reduced[i], borrow = bits.Sub64(x[i], obj.p[i], borrow)
+	}
+	mask := -(hi | (borrow ^ 1))
+	for i := 0; i < obj.n; i++ {
+		x[i] = (reduced[i] & mask) | (x[i] &^ mask)
+	}
+	return x
+}
+
+// cswap swaps a and b when swap is 1 and leaves them when it's 0
+func (obj *field) cswap(a *element, b *element, swap uint64) {
+	mask := -swap
+	for i := 0; i < obj.n; i++ {
+		t := mask & (a[i] ^ b[i])
+		a[i] ^= t
+		b[i] ^= t
+	}
+}
+
+// mulAdd returns a*b + c + d as a 128-bit (hi, lo) pair, which can't overflow
+func mulAdd(a uint64, b uint64, c uint64, d uint64) (uint64, uint64) {
+	hi, lo := bits.Mul64(a, b)
+	var carry uint64
+	lo, carry = bits.Add64(lo, c, 0)
+	hi += carry
+	lo, carry = bits.Add64(lo, d, 0)
+	hi += carry
+	return hi, lo
+}
+
+func reversed(data []byte) []byte {
+	for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+		data[i], data[j] = data[j], data[i]
+	}
+	return data
+}
+
+// _maxLimbs fits the 448-bit field of Curve448
+const _maxLimbs = 7
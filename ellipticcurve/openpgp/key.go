@@ -0,0 +1,188 @@
+package openpgp
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Key is a version 4 OpenPGP ECDSA key. Its creation time is part of the
+// fingerprint, so it must be kept alongside the public key.
+type Key struct {
+	PublicKey publickey.PublicKey
+	CreatedAt time.Time
+	// UserIds holds the user IDs certified by the primary key; it's empty for subkeys
+	UserIds  []string
+	IsSubkey bool
+}
+
+func NewKey(publicKey publickey.PublicKey, createdAt time.Time) Key {
+	return Key{PublicKey: publicKey, CreatedAt: createdAt}
+}
+
+// Fingerprint is the SHA-1 digest of the public key packet (RFC 4880 §12.2)
+func (obj Key) Fingerprint() []byte {
+	digest := sha1.Sum(obj.hashPrefix())
+	return digest[:]
+}
+
+// KeyId is the low 64 bits of the fingerprint
+func (obj Key) KeyId() []byte {
+	return obj.Fingerprint()[12:]
+}
+
+func (obj Key) body() []byte {
+	oid := utils.ByteStringFromHex(utils.OidToHex(obj.PublicKey.Curve.Oid))
+	body := []byte{keyVersion}
+	body = append(body, writeUint32(uint32(obj.CreatedAt.Unix()))...)
+	body = append(body, ecdsaAlgorithm, byte(len(oid)))
+	body = append(body, oid...)
+	return append(body, writeMpi(utils.ByteStringFromHex("04"+obj.PublicKey.ToString(false)))...)
+}
+
+// hashPrefix is how a key enters fingerprints and signature digests
+func (obj Key) hashPrefix() []byte {
+	body := obj.body()
+	return append([]byte{0x99, byte(len(body) >> 8), byte(len(body))}, body...)
+}
+
+// ExportPublicKey returns an armored public key block (as `gpg --export --armor` would) with
+// the primary key, the user ID (e.g. "Statements <statements@example.com>") and its
+// self-certification, so that the key can be imported by other OpenPGP implementations
+func ExportPublicKey(privateKey *privatekey.PrivateKey, createdAt time.Time, userId string, hashfunc ...utils.HashFunc) string {
+	key := NewKey(privateKey.PublicKey(), createdAt)
+	certification := sign(
+		userIdHashInput(key, userId),
+		privateKey,
+		key,
+		positiveCertification,
+		createdAt,
+		[][]byte{writeSubpacket(keyFlagsType, []byte{keyFlagsCertifyAndSign})},
+		hashfunc...,
+	)
+
+	data := writePacket(publicKeyTag, key.body())
+	data = append(data, writePacket(userIdTag, []byte(userId))...)
+	data = append(data, writePacket(signatureTag, certification.body())...)
+	return armor(publicKeyBlock, data)
+}
+
+// KeysFromArmor parses an armored public key block (e.g. from `gpg --export --armor`).
+// It returns the primary key followed by its subkeys. Only ECDSA keys are returned,
+// user IDs are only kept when their self-certification is valid, and subkeys are only
+// kept when their binding signature is.
+func KeysFromArmor(armored string) []Key {
+	return KeysFromBytes(dearmor(armored, publicKeyBlock))
+}
+
+// KeysFromBytes is KeysFromArmor for binary keyrings
+func KeysFromBytes(data []byte) []Key {
+	var keys []Key
+	var primary *Key
+	var subkey *Key
+	var userId []byte
+
+	for _, p := range readPackets(data) {
+		switch p.tag {
+		case publicKeyTag:
+			if primary != nil {
+				panic("OpenPGP key blocks with several primary keys are not supported")
+			}
+			key, ok := parseKey(p.body)
+			if !ok {
+				panic("OpenPGP primary key is not an ECDSA key on a known curve")
+			}
+			primary = &key
+			keys = append(keys, key)
+		case userIdTag:
+			userId = p.body
+			subkey = nil
+		case publicSubkeyTag:
+			userId = nil
+			subkey = nil
+			if key, ok := parseKey(p.body); ok {
+				key.IsSubkey = true
+				subkey = &key
+			}
+		case signatureTag:
+			if primary == nil {
+				continue
+			}
+			sig, ok := parseSignature(p.body)
+			if !ok {
+				continue
+			}
+			switch {
+			case userId != nil && sig.Type >= genericCertification && sig.Type <= positiveCertification:
+				if verify(userIdHashInput(*primary, string(userId)), sig, *primary) {
+					keys[0].UserIds = append(keys[0].UserIds, string(userId))
+					userId = nil
+				}
+			case subkey != nil && sig.Type == subkeyBinding:
+				input := append(primary.hashPrefix(), subkey.hashPrefix()...)
+				if verify(input, sig, *primary) {
+					keys = append(keys, *subkey)
+					subkey = nil
+				}
+			}
+		}
+	}
+	if primary == nil {
+		panic("Missing OpenPGP primary ECDSA key")
+	}
+	return keys
+}
+
+// parseKey decodes a version 4 public key packet body. Keys of other algorithms or
+// on curves this library doesn't know are reported as not ok rather than rejected,
+// since keyrings commonly mix them with ECDSA keys.
+func parseKey(body []byte) (key Key, ok bool) {
+	if len(body) < 7 || body[0] != keyVersion {
+		return key, false
+	}
+	createdAt := time.Unix(int64(binary.BigEndian.Uint32(body[1:5])), 0).UTC()
+	if body[5] != ecdsaAlgorithm {
+		return key, false
+	}
+	oidLength := int(body[6])
+	if len(body) < 7+oidLength {
+		panic("missing bytes in OpenPGP key packet")
+	}
+	oid := utils.OidFromHex(utils.HexFromByteString(body[7 : 7+oidLength]))
+	point, rest := readMpi(body[7+oidLength:])
+	if len(rest) != 0 {
+		panic("unexpected trailing bytes in OpenPGP key packet")
+	}
+	curveFp, err := curve.ByOid(oid)
+	if err != nil {
+		return key, false
+	}
+	if len(point) == 0 || point[0] != 0x04 {
+		panic(fmt.Sprintf("OpenPGP ECDSA keys should hold uncompressed points, got %x", point))
+	}
+	publicKey := publickey.FromString(utils.HexFromByteString(point[1:]), curveFp, true)
+	key = NewKey(publicKey, createdAt)
+	if !bytes.Equal(key.body(), body) {
+		// the fingerprint is computed from our encoding, so it must match the original
+		panic("non-canonical OpenPGP key packet")
+	}
+	return key, true
+}
+
+func userIdHashInput(key Key, userId string) []byte {
+	input := append(key.hashPrefix(), 0xb4)
+	input = append(input, writeUint32(uint32(len(userId)))...)
+	return append(input, userId...)
+}
+
+const (
+	publicKeyBlock         = "PUBLIC KEY BLOCK"
+	keyFlagsCertifyAndSign = 0x03
+)
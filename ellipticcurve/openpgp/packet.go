@@ -0,0 +1,244 @@
+package openpgp
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+	"strings"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+type packet struct {
+	tag  byte
+	body []byte
+}
+
+// readPackets splits data into OpenPGP packets (RFC 4880 §4.2), accepting both
+// old and new format headers, including partial body lengths
+func readPackets(data []byte) []packet {
+	var packets []packet
+	for len(data) > 0 {
+		header := data[0]
+		if header&0x80 == 0 {
+			panic("OpenPGP packet header should have its high bit set")
+		}
+		data = data[1:]
+
+		var tag byte
+		var body []byte
+		if header&0x40 != 0 {
+			tag = header & 0x3f
+			for {
+				length, lengthLength, partial := readNewLength(data)
+				if len(data) < lengthLength+length {
+					panic("missing bytes in OpenPGP packet")
+				}
+				body = append(body, data[lengthLength:lengthLength+length]...)
+				data = data[lengthLength+length:]
+				if !partial {
+					break
+				}
+			}
+		} else {
+			tag = (header >> 2) & 0x0f
+			lengthLength := []int{1, 2, 4, 0}[header&0x03]
+			if lengthLength == 0 {
+				panic("indeterminate OpenPGP packet lengths are not supported")
+			}
+			if len(data) < lengthLength {
+				panic("missing bytes in OpenPGP packet length")
+			}
+			length := 0
+			for _, b := range data[:lengthLength] {
+				length = length<<8 | int(b)
+			}
+			if len(data) < lengthLength+length {
+				panic("missing bytes in OpenPGP packet")
+			}
+			body = data[lengthLength : lengthLength+length]
+			data = data[lengthLength+length:]
+		}
+		packets = append(packets, packet{tag: tag, body: body})
+	}
+	return packets
+}
+
+func readNewLength(data []byte) (length int, lengthLength int, partial bool) {
+	if len(data) < 1 {
+		panic("missing bytes in OpenPGP packet length")
+	}
+	switch first := int(data[0]); {
+	case first < 192:
+		return first, 1, false
+	case first < 224:
+		if len(data) < 2 {
+			panic("missing bytes in OpenPGP packet length")
+		}
+		return (first-192)<<8 + int(data[1]) + 192, 2, false
+	case first < 255:
+		return 1 << (first & 0x1f), 1, true
+	default:
+		if len(data) < 5 {
+			panic("missing bytes in OpenPGP packet length")
+		}
+		return int(binary.BigEndian.Uint32(data[1:5])), 5, false
+	}
+}
+
+// writePacket encodes a packet with a new format header
+func writePacket(tag byte, body []byte) []byte {
+	return append(append([]byte{0xc0 | tag}, encodeLength(len(body))...), body...)
+}
+
+// encodeLength encodes packet and subpacket lengths (RFC 4880 §4.2.2)
+func encodeLength(length int) []byte {
+	switch {
+	case length < 192:
+		return []byte{byte(length)}
+	case length < 8384:
+		length -= 192
+		return []byte{byte(length>>8) + 192, byte(length)}
+	default:
+		return append([]byte{0xff}, writeUint32(uint32(length))...)
+	}
+}
+
+// writeUint32 encodes the four-octet big-endian numbers of lengths and timestamps
+func writeUint32(n uint32) []byte {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, n)
+	return data
+}
+
+// readMpi reads a multiprecision integer: a two-octet bit count followed by the bytes
+func readMpi(data []byte) ([]byte, []byte) {
+	if len(data) < 2 {
+		panic("missing bytes in OpenPGP MPI")
+	}
+	length := (int(binary.BigEndian.Uint16(data)) + 7) / 8
+	if len(data) < 2+length {
+		panic("missing bytes in OpenPGP MPI")
+	}
+	return data[2 : 2+length], data[2+length:]
+}
+
+func writeMpi(value []byte) []byte {
+	for len(value) > 0 && value[0] == 0 {
+		value = value[1:]
+	}
+	bitLength := 0
+	if len(value) > 0 {
+		bitLength = 8*(len(value)-1) + bits.Len8(value[0])
+	}
+	return append([]byte{byte(bitLength >> 8), byte(bitLength)}, value...)
+}
+
+type subpacket struct {
+	kind byte
+	data []byte
+}
+
+func readSubpackets(data []byte) []subpacket {
+	var subpackets []subpacket
+	for len(data) > 0 {
+		length, lengthLength, partial := readNewLength(data)
+		if partial || length == 0 || len(data) < lengthLength+length {
+			panic("malformed OpenPGP signature subpacket")
+		}
+		body := data[lengthLength : lengthLength+length]
+		subpackets = append(subpackets, subpacket{kind: body[0] & 0x7f, data: body[1:]})
+		data = data[lengthLength+length:]
+	}
+	return subpackets
+}
+
+func writeSubpacket(kind byte, data []byte) []byte {
+	return append(append(encodeLength(len(data)+1), kind), data...)
+}
+
+// armor encodes data in ASCII armor (RFC 4880 §6.2) with its CRC-24 checksum
+func armor(blockType string, data []byte) string {
+	checksum := crc24(data)
+	template := fmt.Sprintf(
+		"-----BEGIN PGP %s-----\n\n{content}\n=%s\n-----END PGP %s-----\n",
+		blockType,
+		base64.StdEncoding.EncodeToString([]byte{byte(checksum >> 16), byte(checksum >> 8), byte(checksum)}),
+		blockType,
+	)
+	return utils.CreatePem(utils.Base64FromByteString(data), template)
+}
+
+// dearmor decodes the first armored block of the given type, skipping armor headers
+// and checking the CRC-24 checksum when present
+func dearmor(armored string, blockType string) []byte {
+	begin := "-----BEGIN PGP " + blockType + "-----"
+	end := "-----END PGP " + blockType + "-----"
+	start := strings.Index(armored, begin)
+	if start < 0 {
+		panic(fmt.Sprintf("Missing %v in OpenPGP armor", begin))
+	}
+	lines := strings.Split(strings.ReplaceAll(armored[start+len(begin):], "\r\n", "\n"), "\n")
+
+	index := 1
+	for index < len(lines) && strings.TrimSpace(lines[index]) != "" {
+		if !strings.Contains(lines[index], ": ") {
+			break // some encoders omit the blank line when there are no headers
+		}
+		index++
+	}
+	content := ""
+	checksum := ""
+	for ; index < len(lines); index++ {
+		line := strings.TrimSpace(lines[index])
+		if line == end {
+			data, err := base64.StdEncoding.DecodeString(content)
+			if err != nil {
+				panic(fmt.Sprintf("Invalid base64 in OpenPGP armor: %v", err))
+			}
+			if checksum != "" {
+				expected, _ := base64.StdEncoding.DecodeString(checksum)
+				actual := crc24(data)
+				if len(expected) != 3 || expected[0] != byte(actual>>16) || expected[1] != byte(actual>>8) || expected[2] != byte(actual) {
+					panic("OpenPGP armor checksum mismatch")
+				}
+			}
+			return data
+		}
+		if strings.HasPrefix(line, "=") && len(line) == 5 {
+			checksum = line[1:]
+			continue
+		}
+		content += line
+	}
+	panic(fmt.Sprintf("Missing %v in OpenPGP armor", end))
+}
+
+func crc24(data []byte) uint32 {
+	crc := uint32(0xb704ce)
+	for _, b := range data {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= 0x1864cfb
+			}
+		}
+	}
+	return crc & 0xffffff
+}
+
+const (
+	signatureTag          = 2
+	publicKeyTag          = 6
+	userIdTag             = 13
+	publicSubkeyTag       = 14
+	ecdsaAlgorithm        = 19
+	keyVersion            = 4
+	signatureVersion      = 4
+	creationTimeType      = 2
+	issuerKeyIdType       = 16
+	keyFlagsType          = 27
+	issuerFingerprintType = 33
+)
@@ -0,0 +1,352 @@
+//
+// OpenPGP ECDSA keys and signatures, interoperable with GnuPG
+//
+// https://www.rfc-editor.org/rfc/rfc4880
+// https://www.rfc-editor.org/rfc/rfc6637
+//
+
+package openpgp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Signature is a version 4 OpenPGP signature packet
+type Signature struct {
+	Type               byte
+	HashAlgorithm      byte
+	CreatedAt          time.Time
+	IssuerKeyId        []byte
+	IssuerFingerprint  []byte
+	Signature          signature.Signature
+	hashedSubpackets   []byte
+	unhashedSubpackets []byte
+	hashPrefix         []byte
+}
+
+const (
+	// BinarySignature signs the message bytes as they are
+	BinarySignature = 0x00
+	// TextSignature signs the message with its line endings converted to CRLF
+	TextSignature = 0x01
+
+	genericCertification  = 0x10
+	positiveCertification = 0x13
+	subkeyBinding         = 0x18
+)
+
+// Sign creates a detached binary signature, as `gpg --detach-sign` does. The key creation
+// time is needed to compute the issuer fingerprint, so it must match the exported key.
+// The hash defaults to the one matching the curve size (sha256 for P-256).
+func Sign(message string, privateKey *privatekey.PrivateKey, keyCreatedAt time.Time, hashfunc ...utils.HashFunc) Signature {
+	key := NewKey(privateKey.PublicKey(), keyCreatedAt)
+	return sign([]byte(message), privateKey, key, BinarySignature, time.Now(), nil, hashfunc...)
+}
+
+// SignText creates a detached text signature, as `gpg --textmode --detach-sign` does
+func SignText(message string, privateKey *privatekey.PrivateKey, keyCreatedAt time.Time, hashfunc ...utils.HashFunc) Signature {
+	key := NewKey(privateKey.PublicKey(), keyCreatedAt)
+	return sign(canonicalText(message), privateKey, key, TextSignature, time.Now(), nil, hashfunc...)
+}
+
+// Verify checks that sig is a valid binary or text signature over message made by key
+func Verify(message string, sig Signature, key Key) bool {
+	switch sig.Type {
+	case BinarySignature:
+		return verify([]byte(message), sig, key)
+	case TextSignature:
+		return verify(canonicalText(message), sig, key)
+	default:
+		return false
+	}
+}
+
+// FindKey returns the key among keys (e.g. a primary key and its subkeys) that issued sig
+func FindKey(keys []Key, sig Signature) (Key, bool) {
+	for _, key := range keys {
+		if bytes.Equal(key.Fingerprint(), sig.IssuerFingerprint) || bytes.Equal(key.KeyId(), sig.IssuerKeyId) {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+// SignCleartext returns message wrapped in a cleartext signature framework, as
+// `gpg --clearsign` does. Trailing whitespace on each line is not covered by the
+// signature, and the final line break belongs to the framework.
+func SignCleartext(message string, privateKey *privatekey.PrivateKey, keyCreatedAt time.Time, hashfunc ...utils.HashFunc) string {
+	key := NewKey(privateKey.PublicKey(), keyCreatedAt)
+	lines := strings.Split(strings.TrimSuffix(strings.ReplaceAll(message, "\r\n", "\n"), "\n"), "\n")
+	sig := sign(cleartextHashInput(lines), privateKey, key, TextSignature, time.Now(), nil, hashfunc...)
+
+	escaped := make([]string, len(lines))
+	for i, line := range lines {
+		if strings.HasPrefix(line, "-") {
+			line = "- " + line
+		}
+		escaped[i] = line
+	}
+	return fmt.Sprintf(
+		"%v\nHash: %v\n\n%v\n%v",
+		cleartextHeader,
+		hashNames[sig.HashAlgorithm],
+		strings.Join(escaped, "\n"),
+		sig.ToArmor(),
+	)
+}
+
+// VerifyCleartext checks a `gpg --clearsign` message and returns its text, with dash
+// escapes removed and LF line endings
+func VerifyCleartext(armored string, key Key) (string, bool) {
+	lines := strings.Split(strings.ReplaceAll(armored, "\r\n", "\n"), "\n")
+	index := 0
+	for index < len(lines) && strings.TrimSpace(lines[index]) != cleartextHeader {
+		index++
+	}
+	if index == len(lines) {
+		panic(fmt.Sprintf("Missing %v in OpenPGP cleartext", cleartextHeader))
+	}
+
+	var hashes []string
+	for index++; index < len(lines) && strings.TrimSpace(lines[index]) != ""; index++ {
+		if value := strings.TrimPrefix(lines[index], "Hash: "); value != lines[index] {
+			for _, name := range strings.Split(value, ",") {
+				hashes = append(hashes, strings.TrimSpace(name))
+			}
+		}
+	}
+
+	var text []string
+	for index++; index < len(lines) && lines[index] != signatureHeader; index++ {
+		text = append(text, strings.TrimPrefix(lines[index], "- "))
+	}
+	if index == len(lines) {
+		panic(fmt.Sprintf("Missing %v in OpenPGP cleartext", signatureHeader))
+	}
+
+	sig := SignatureFromArmor(strings.Join(lines[index:], "\n"))
+	if sig.Type != TextSignature {
+		return "", false
+	}
+	if len(hashes) > 0 && !contains(hashes, hashNames[sig.HashAlgorithm]) {
+		return "", false
+	}
+	if !verify(cleartextHashInput(text), sig, key) {
+		return "", false
+	}
+	return strings.Join(text, "\n"), true
+}
+
+func (obj Signature) ToBytes() []byte {
+	return writePacket(signatureTag, obj.body())
+}
+
+// ToArmor returns the signature in the "BEGIN PGP SIGNATURE" armored format written by `gpg --armor`
+func (obj Signature) ToArmor() string {
+	return armor(signatureBlock, obj.ToBytes())
+}
+
+// SignatureFromBytes reads the first ECDSA signature packet in data, e.g. a .sig file
+func SignatureFromBytes(data []byte) Signature {
+	for _, p := range readPackets(data) {
+		if p.tag != signatureTag {
+			continue
+		}
+		if sig, ok := parseSignature(p.body); ok {
+			return sig
+		}
+	}
+	panic("Missing OpenPGP ECDSA signature packet")
+}
+
+// SignatureFromArmor reads a "BEGIN PGP SIGNATURE" block, e.g. a .asc file
+func SignatureFromArmor(armored string) Signature {
+	return SignatureFromBytes(dearmor(armored, signatureBlock))
+}
+
+func sign(data []byte, privateKey *privatekey.PrivateKey, key Key, sigType byte, createdAt time.Time, subpackets [][]byte, hashfunc ...utils.HashFunc) Signature {
+	hf := defaultHash(key)
+	if len(hashfunc) > 0 {
+		hf = hashfunc[0]
+	}
+	hashAlgorithm, ok := hashIds[hf().Size()]
+	if !ok {
+		panic(fmt.Sprintf("OpenPGP signatures don't support %v byte digests", hf().Size()))
+	}
+
+	fingerprint := key.Fingerprint()
+	hashed := writeSubpacket(issuerFingerprintType, append([]byte{keyVersion}, fingerprint...))
+	hashed = append(hashed, writeSubpacket(creationTimeType, writeUint32(uint32(createdAt.Unix())))...)
+	for _, subpacket := range subpackets {
+		hashed = append(hashed, subpacket...)
+	}
+
+	sig := Signature{
+		Type:               sigType,
+		HashAlgorithm:      hashAlgorithm,
+		CreatedAt:          time.Unix(createdAt.Unix(), 0).UTC(),
+		IssuerKeyId:        key.KeyId(),
+		IssuerFingerprint:  fingerprint,
+		hashedSubpackets:   hashed,
+		unhashedSubpackets: writeSubpacket(issuerKeyIdType, key.KeyId()),
+	}
+	hashInput := append(append([]byte{}, data...), sig.trailer()...)
+	h := hf()
+	h.Write(hashInput)
+	sig.hashPrefix = h.Sum(nil)[:2]
+	sig.Signature = ecdsa.Sign(string(hashInput), privateKey, hf)
+	return sig
+}
+
+// defaultHash is the hash RFC 6637 §5 pairs with the key's curve: SHA2-256 up to
+// 256 bits (P-256), SHA2-384 up to 384 bits (P-384) and SHA2-512 above (P-521)
+func defaultHash(key Key) utils.HashFunc {
+	switch bitLength := key.PublicKey.Curve.NBitLength; {
+	case bitLength <= 256:
+		return utils.Sha256
+	case bitLength <= 384:
+		return utils.Sha384
+	default:
+		return utils.Sha512
+	}
+}
+
+func verify(data []byte, sig Signature, key Key) bool {
+	hf, ok := hashFuncs[sig.HashAlgorithm]
+	if !ok {
+		return false
+	}
+	hashInput := append(append([]byte{}, data...), sig.trailer()...)
+	return ecdsa.Verify(string(hashInput), sig.Signature, &key.PublicKey, hf)
+}
+
+// hashedPortion is the part of the packet covered by the signature
+func (obj Signature) hashedPortion() []byte {
+	portion := []byte{signatureVersion, obj.Type, ecdsaAlgorithm, obj.HashAlgorithm}
+	portion = append(portion, byte(len(obj.hashedSubpackets)>>8), byte(len(obj.hashedSubpackets)))
+	return append(portion, obj.hashedSubpackets...)
+}
+
+// trailer is appended to the signed data before hashing (RFC 4880 §5.2.4)
+func (obj Signature) trailer() []byte {
+	portion := obj.hashedPortion()
+	trailer := append(portion, signatureVersion, 0xff)
+	return append(trailer, writeUint32(uint32(len(portion)))...)
+}
+
+func (obj Signature) body() []byte {
+	body := obj.hashedPortion()
+	body = append(body, byte(len(obj.unhashedSubpackets)>>8), byte(len(obj.unhashedSubpackets)))
+	body = append(body, obj.unhashedSubpackets...)
+	body = append(body, obj.hashPrefix...)
+	body = append(body, writeMpi(obj.Signature.R.Bytes())...)
+	return append(body, writeMpi(obj.Signature.S.Bytes())...)
+}
+
+// parseSignature decodes a version 4 signature packet body. Signatures by other
+// algorithms or with unknown hashes are reported as not ok rather than rejected.
+func parseSignature(body []byte) (sig Signature, ok bool) {
+	if len(body) < 6 || body[0] != signatureVersion {
+		return sig, false
+	}
+	if body[2] != ecdsaAlgorithm {
+		return sig, false
+	}
+	if _, known := hashFuncs[body[3]]; !known {
+		return sig, false
+	}
+	sig.Type = body[1]
+	sig.HashAlgorithm = body[3]
+
+	rest := body[4:]
+	sig.hashedSubpackets, rest = readSubpacketArea(rest)
+	sig.unhashedSubpackets, rest = readSubpacketArea(rest)
+	if len(rest) < 2 {
+		panic("missing bytes in OpenPGP signature packet")
+	}
+	sig.hashPrefix = rest[:2]
+	r, rest := readMpi(rest[2:])
+	s, rest := readMpi(rest)
+	if len(rest) != 0 {
+		panic("unexpected trailing bytes in OpenPGP signature packet")
+	}
+	sig.Signature = signature.New(*utils.NumberFromByteString(r), *utils.NumberFromByteString(s))
+
+	for _, subpacket := range append(readSubpackets(sig.hashedSubpackets), readSubpackets(sig.unhashedSubpackets)...) {
+		switch {
+		case subpacket.kind == creationTimeType && len(subpacket.data) == 4:
+			sig.CreatedAt = time.Unix(int64(binary.BigEndian.Uint32(subpacket.data)), 0).UTC()
+		case subpacket.kind == issuerKeyIdType && len(subpacket.data) == 8:
+			sig.IssuerKeyId = subpacket.data
+		case subpacket.kind == issuerFingerprintType && len(subpacket.data) == 21 && subpacket.data[0] == keyVersion:
+			sig.IssuerFingerprint = subpacket.data[1:]
+		}
+	}
+	return sig, true
+}
+
+func readSubpacketArea(data []byte) ([]byte, []byte) {
+	if len(data) < 2 {
+		panic("missing bytes in OpenPGP signature packet")
+	}
+	length := int(binary.BigEndian.Uint16(data))
+	if len(data) < 2+length {
+		panic("missing bytes in OpenPGP signature packet")
+	}
+	return data[2 : 2+length], data[2+length:]
+}
+
+func canonicalText(message string) []byte {
+	return []byte(strings.ReplaceAll(strings.ReplaceAll(message, "\r\n", "\n"), "\n", "\r\n"))
+}
+
+// cleartextHashInput is the signed form of cleartext lines (RFC 4880 §7.1)
+func cleartextHashInput(lines []string) []byte {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, " \t")
+	}
+	return []byte(strings.Join(trimmed, "\r\n"))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	signatureBlock  = "SIGNATURE"
+	cleartextHeader = "-----BEGIN PGP SIGNED MESSAGE-----"
+	signatureHeader = "-----BEGIN PGP SIGNATURE-----"
+)
+
+var hashFuncs = map[byte]utils.HashFunc{
+	8:  utils.Sha256,
+	9:  utils.Sha384,
+	10: utils.Sha512,
+}
+
+var hashIds = map[int]byte{
+	32: 8,
+	48: 9,
+	64: 10,
+}
+
+var hashNames = map[byte]string{
+	8:  "SHA256",
+	9:  "SHA384",
+	10: "SHA512",
+}
@@ -0,0 +1,247 @@
+package pkcs12
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"unicode/utf16"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// encrypt applies PBES2 (RFC 8018 §6.2) with PBKDF2-HMAC-SHA256 and AES-256-CBC, the
+// OpenSSL 3 default, and returns the hex DER AlgorithmIdentifier with the ciphertext
+func encrypt(plaintext []byte, password string, iterations int) (string, []byte) {
+	salt := randomBytes(8)
+	iv := randomBytes(aes.BlockSize)
+	key := pbkdf2([]byte(password), salt, iterations, 32, utils.Sha256)
+
+	block, _ := aes.NewCipher(key)
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	ciphertext := append(append([]byte{}, plaintext...), bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+
+	algorithm := utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Object, _pbes2Oid),
+		utils.EncodeConstructed(
+			utils.EncodeConstructed(
+				utils.EncodePrimitive(utils.Object, _pbkdf2Oid),
+				utils.EncodeConstructed(
+					utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(salt)),
+					utils.EncodePrimitive(utils.Integer, big.NewInt(int64(iterations))),
+					utils.EncodeConstructed(
+						utils.EncodePrimitive(utils.Object, _hmacWithSha256Oid),
+						utils.EncodePrimitive(utils.Null, ""),
+					),
+				),
+			),
+			utils.EncodeConstructed(
+				utils.EncodePrimitive(utils.Object, _aes256CbcOid),
+				utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(iv)),
+			),
+		),
+	)
+	return algorithm, ciphertext
+}
+
+// decrypt reverses encrypt for any PBES2 PBKDF2/AES-CBC combination, and also reads the
+// legacy pbeWithSHAAnd3-KeyTripleDES-CBC scheme OpenSSL 1.x used for private keys
+func decrypt(algorithm utils.Element, ciphertext []byte, password string) []byte {
+	fields := sequenceFields(algorithm.Raw, "encryption AlgorithmIdentifier", 2)
+	oid := utils.ParseOid(fields[0].Content)
+
+	var block cipher.Block
+	var iv []byte
+	switch {
+	case curve.IsOidEqual(oid, _pbes2Oid):
+		block, iv = pbes2Cipher(fields[1].Raw, password)
+	case curve.IsOidEqual(oid, _pbeWithSha1And3DesOid):
+		params := sequenceFields(fields[1].Raw, "PBE parameters", 2)
+		salt := utils.ByteStringFromHex(params[0].Content)
+		iterations := int(utils.ParseInteger(params[1].Content).Int64())
+		bmpPassword := bmpString(password)
+		key := pkcs12Kdf(sha1.New, bmpPassword, salt, _encryptionKeyId, iterations, 24)
+		iv = pkcs12Kdf(sha1.New, bmpPassword, salt, _ivId, iterations, des.BlockSize)
+		block, _ = des.NewTripleDESCipher(key)
+	default:
+		panic(fmt.Sprintf("Unsupported PKCS#12 encryption algorithm %v", oid))
+	}
+
+	if len(ciphertext) == 0 || len(ciphertext)%block.BlockSize() != 0 {
+		panic("PKCS#12 ciphertext is not a whole number of blocks")
+	}
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > block.BlockSize() || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		panic("PKCS#12 decryption failed: wrong password or corrupted file")
+	}
+	return plaintext[:len(plaintext)-padding]
+}
+
+func pbes2Cipher(paramsHex string, password string) (cipher.Block, []byte) {
+	params := sequenceFields(paramsHex, "PBES2 parameters", 2)
+
+	kdf := sequenceFields(params[0].Raw, "PBES2 key derivation function", 2)
+	if kdfOid := utils.ParseOid(kdf[0].Content); !curve.IsOidEqual(kdfOid, _pbkdf2Oid) {
+		panic(fmt.Sprintf("Unsupported PBES2 key derivation function %v", kdfOid))
+	}
+	kdfParams := sequenceFields(kdf[1].Raw, "PBKDF2 parameters", 2)
+	salt := utils.ByteStringFromHex(kdfParams[0].Content)
+	iterations := int(utils.ParseInteger(kdfParams[1].Content).Int64())
+	prf := utils.HashFunc(sha1.New)
+	for _, field := range kdfParams[2:] {
+		if field.Tag != _sequenceTag {
+			continue // the optional keyLength, which the encryption scheme already implies
+		}
+		prfOid := utils.ParseOid(utils.ParseElements(field.Content)[0].Content)
+		hf, ok := _prfFuncs[oidKey(prfOid)]
+		if !ok {
+			panic(fmt.Sprintf("Unsupported PBKDF2 pseudorandom function %v", prfOid))
+		}
+		prf = hf
+	}
+
+	scheme := sequenceFields(params[1].Raw, "PBES2 encryption scheme", 2)
+	schemeOid := utils.ParseOid(scheme[0].Content)
+	keyLength, ok := _aesKeyLengths[oidKey(schemeOid)]
+	if !ok {
+		panic(fmt.Sprintf("Unsupported PBES2 encryption scheme %v", schemeOid))
+	}
+	iv := utils.ByteStringFromHex(scheme[1].Content)
+	if len(iv) != aes.BlockSize {
+		panic("PBES2 AES-CBC initialization vectors should be 16 bytes long")
+	}
+
+	block, _ := aes.NewCipher(pbkdf2([]byte(password), salt, iterations, keyLength, prf))
+	return block, iv
+}
+
+// pbkdf2 derives a key as in RFC 8018 §5.2
+func pbkdf2(password []byte, salt []byte, iterations int, keyLength int, hf utils.HashFunc) []byte {
+	prf := hmac.New(hf, password)
+	var key []byte
+	for block := uint32(1); len(key) < keyLength; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.Write(prf, binary.BigEndian, block)
+		u := prf.Sum(nil)
+		t := append([]byte{}, u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+	return key[:keyLength]
+}
+
+// pkcs12Kdf derives keys, IVs and MAC keys from a BMPString password (RFC 7292 Appendix B.2)
+func pkcs12Kdf(hf utils.HashFunc, password []byte, salt []byte, id byte, iterations int, size int) []byte {
+	h := hf()
+	v := h.BlockSize()
+	diversifier := bytes.Repeat([]byte{id}, v)
+	input := append(fillBlocks(salt, v), fillBlocks(password, v)...)
+
+	var result []byte
+	for {
+		h.Reset()
+		h.Write(diversifier)
+		h.Write(input)
+		a := h.Sum(nil)
+		for i := 1; i < iterations; i++ {
+			h.Reset()
+			h.Write(a)
+			a = h.Sum(a[:0])
+		}
+		result = append(result, a...)
+		if len(result) >= size {
+			return result[:size]
+		}
+
+		// each v-byte block of the input becomes (block + B + 1) mod 2^(8v)
+		b := fillBlocks(a, v)
+		for j := 0; j < len(input); j += v {
+			carry := 1
+			for k := v - 1; k >= 0; k-- {
+				sum := int(input[j+k]) + int(b[k]) + carry
+				input[j+k] = byte(sum)
+				carry = sum >> 8
+			}
+		}
+	}
+}
+
+// fillBlocks repeats data up to the next multiple of v bytes
+func fillBlocks(data []byte, v int) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	filled := make([]byte, v*((len(data)+v-1)/v))
+	for i := range filled {
+		filled[i] = data[i%len(data)]
+	}
+	return filled
+}
+
+// bmpString encodes the password as null terminated UTF-16BE, as the PKCS#12 KDF expects
+func bmpString(password string) []byte {
+	var encoded []byte
+	for _, unit := range utf16.Encode([]rune(password)) {
+		encoded = append(encoded, byte(unit>>8), byte(unit))
+	}
+	return append(encoded, 0, 0)
+}
+
+func randomBytes(size int) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		panic(err)
+	}
+	return data
+}
+
+const (
+	_encryptionKeyId = 1
+	_ivId            = 2
+	_macKeyId        = 3
+)
+
+var _pbes2Oid = []int64{1, 2, 840, 113549, 1, 5, 13}
+var _pbkdf2Oid = []int64{1, 2, 840, 113549, 1, 5, 12}
+var _pbeWithSha1And3DesOid = []int64{1, 2, 840, 113549, 1, 12, 1, 3}
+
+var _hmacWithSha1Oid = []int64{1, 2, 840, 113549, 2, 7}
+var _hmacWithSha256Oid = []int64{1, 2, 840, 113549, 2, 9}
+var _hmacWithSha384Oid = []int64{1, 2, 840, 113549, 2, 10}
+var _hmacWithSha512Oid = []int64{1, 2, 840, 113549, 2, 11}
+
+var _aes128CbcOid = []int64{2, 16, 840, 1, 101, 3, 4, 1, 2}
+var _aes192CbcOid = []int64{2, 16, 840, 1, 101, 3, 4, 1, 22}
+var _aes256CbcOid = []int64{2, 16, 840, 1, 101, 3, 4, 1, 42}
+
+var _prfFuncs = map[string]utils.HashFunc{
+	oidKey(_hmacWithSha1Oid):   sha1.New,
+	oidKey(_hmacWithSha256Oid): utils.Sha256,
+	oidKey(_hmacWithSha384Oid): utils.Sha384,
+	oidKey(_hmacWithSha512Oid): utils.Sha512,
+}
+
+var _aesKeyLengths = map[string]int{
+	oidKey(_aes128CbcOid): 16,
+	oidKey(_aes192CbcOid): 24,
+	oidKey(_aes256CbcOid): 32,
+}
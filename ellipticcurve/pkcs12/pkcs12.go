@@ -0,0 +1,324 @@
+//
+// PKCS#12 (.p12 / .pfx) bundles holding an EC private key and its certificate chain
+//
+// https://datatracker.ietf.org/doc/html/rfc7292
+// https://datatracker.ietf.org/doc/html/rfc8018#section-6.2
+//
+
+package pkcs12
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Decode reads a PKCS#12 file and returns its private key and certificates (DER). The
+// certificate whose localKeyId matches the key's comes first; the others keep the order
+// they have in the file. The MAC is checked before anything is decrypted.
+func Decode(pfx []byte, password string) (privatekey.PrivateKey, [][]byte) {
+	fields := sequenceFields(utils.BerToDer(utils.HexFromByteString(pfx)), "PFX", 2)
+	if version := utils.ParseInteger(fields[0].Content); version.Cmp(big.NewInt(3)) != 0 {
+		panic(fmt.Sprintf("PKCS#12 files should have version 3, but %v was found instead", version))
+	}
+
+	contentType, authSafe := parseContentInfo(fields[1])
+	if !curve.IsOidEqual(contentType, _dataOid) {
+		panic(fmt.Sprintf("Unsupported PKCS#12 integrity mode %v: only password integrity is supported", contentType))
+	}
+	if len(fields) > 2 {
+		verifyMac(fields[2], authSafe, password)
+	}
+
+	var bags []safeBag
+	for _, contentInfo := range sequenceFields(utils.HexFromByteString(authSafe), "AuthenticatedSafe", 0) {
+		contentType, content := parseContentInfo(contentInfo)
+		switch {
+		case curve.IsOidEqual(contentType, _dataOid):
+		case curve.IsOidEqual(contentType, _encryptedDataOid):
+			content = decryptEncryptedData(content, password)
+		default:
+			panic(fmt.Sprintf("Unsupported PKCS#12 content type %v", contentType))
+		}
+		bags = append(bags, parseSafeContents(content)...)
+	}
+
+	var keyBags, certBags []safeBag
+	for _, bag := range bags {
+		switch {
+		case curve.IsOidEqual(bag.id, _keyBagOid), curve.IsOidEqual(bag.id, _shroudedKeyBagOid):
+			keyBags = append(keyBags, bag)
+		case curve.IsOidEqual(bag.id, _certBagOid):
+			certBags = append(certBags, bag)
+		}
+	}
+	if len(keyBags) != 1 {
+		panic(fmt.Sprintf("PKCS#12 files should hold exactly one private key, but %v were found", len(keyBags)))
+	}
+
+	var leaf, chain [][]byte
+	for _, bag := range certBags {
+		fields := sequenceFields(bag.value.Raw, "CertBag", 2)
+		if certType := utils.ParseOid(fields[0].Content); !curve.IsOidEqual(certType, _x509CertificateOid) {
+			continue // e.g. SDSI certificates
+		}
+		certificate := utils.ByteStringFromHex(explicitOctetString(fields[1]))
+		if bag.localKeyId != "" && bag.localKeyId == keyBags[0].localKeyId {
+			leaf = append(leaf, certificate)
+		} else {
+			chain = append(chain, certificate)
+		}
+	}
+	return parseKeyBag(keyBags[0], password), append(leaf, chain...)
+}
+
+// Encode writes privateKey and its certificates (DER, leaf first) as a PKCS#12 file with
+// OpenSSL 3's default profile: certificates and key encrypted with PBES2 (PBKDF2-HMAC-SHA256,
+// AES-256-CBC) and the whole file authenticated with an HMAC-SHA256 MAC. Iterations
+// default to 2048, as in OpenSSL.
+func Encode(privateKey *privatekey.PrivateKey, certificates [][]byte, password string, iterations ...int) []byte {
+	iterationCount := _defaultIterations
+	if len(iterations) > 0 {
+		iterationCount = iterations[0]
+	}
+
+	attributes := ""
+	if len(certificates) > 0 {
+		localKeyId := sha1.Sum(certificates[0])
+		attributes = encodeAttribute(_localKeyIdOid, utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(localKeyId[:])))
+	}
+
+	contentInfos := ""
+	if len(certificates) > 0 {
+		certBags := ""
+		for i, certificate := range certificates {
+			certBag := utils.EncodeConstructed(
+				utils.EncodePrimitive(utils.Object, _x509CertificateOid),
+				utils.EncodeTag(_explicit0Tag, utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(certificate))),
+			)
+			bagAttributes := ""
+			if i == 0 {
+				bagAttributes = attributes
+			}
+			certBags += encodeSafeBag(_certBagOid, certBag, bagAttributes)
+		}
+		algorithm, ciphertext := encrypt(utils.ByteStringFromHex(utils.EncodeConstructed(certBags)), password, iterationCount)
+		encryptedData := utils.EncodeConstructed(
+			utils.EncodePrimitive(utils.Integer, big.NewInt(0)),
+			utils.EncodeConstructed(
+				utils.EncodePrimitive(utils.Object, _dataOid),
+				algorithm,
+				utils.EncodeTag(_implicit0PrimitiveTag, utils.HexFromByteString(ciphertext)),
+			),
+		)
+		contentInfos += encodeContentInfo(_encryptedDataOid, encryptedData)
+	}
+
+	algorithm, ciphertext := encrypt(privateKey.ToPkcs8Der(), password, iterationCount)
+	keyBag := encodeSafeBag(
+		_shroudedKeyBagOid,
+		utils.EncodeConstructed(algorithm, utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(ciphertext))),
+		attributes,
+	)
+	contentInfos += encodeContentInfo(_dataOid, utils.EncodePrimitive(utils.OctetString, utils.EncodeConstructed(keyBag)))
+	authSafe := utils.ByteStringFromHex(utils.EncodeConstructed(contentInfos))
+
+	salt := randomBytes(8)
+	macKey := pkcs12Kdf(utils.Sha256, bmpString(password), salt, _macKeyId, iterationCount, 32)
+	mac := hmac.New(utils.Sha256, macKey)
+	mac.Write(authSafe)
+	macData := utils.EncodeConstructed(
+		utils.EncodeConstructed(
+			utils.EncodeConstructed(
+				utils.EncodePrimitive(utils.Object, _sha256Oid),
+				utils.EncodePrimitive(utils.Null, ""),
+			),
+			utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(mac.Sum(nil))),
+		),
+		utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(salt)),
+		utils.EncodePrimitive(utils.Integer, big.NewInt(int64(iterationCount))),
+	)
+
+	hexadecimal := utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Integer, big.NewInt(3)),
+		encodeContentInfo(_dataOid, utils.EncodePrimitive(utils.OctetString, utils.HexFromByteString(authSafe))),
+		macData,
+	)
+	return utils.ByteStringFromHex(hexadecimal)
+}
+
+type safeBag struct {
+	id         []int64
+	value      utils.Element
+	localKeyId string
+}
+
+func parseSafeContents(content []byte) []safeBag {
+	var bags []safeBag
+	for _, element := range sequenceFields(utils.HexFromByteString(content), "SafeContents", 0) {
+		fields := sequenceFields(element.Raw, "SafeBag", 2)
+		if fields[1].Tag != _explicit0Tag {
+			panic("PKCS#12 SafeBag values should be explicitly tagged [0]")
+		}
+		bag := safeBag{
+			id:    utils.ParseOid(fields[0].Content),
+			value: utils.ParseElements(fields[1].Content)[0],
+		}
+		if len(fields) > 2 {
+			for _, attribute := range utils.ParseElements(fields[2].Content) {
+				attributeFields := sequenceFields(attribute.Raw, "bag attribute", 2)
+				values := utils.ParseElements(attributeFields[1].Content)
+				if curve.IsOidEqual(utils.ParseOid(attributeFields[0].Content), _localKeyIdOid) && len(values) > 0 {
+					bag.localKeyId = values[0].Content
+				}
+			}
+		}
+		bags = append(bags, bag)
+	}
+	return bags
+}
+
+func parseKeyBag(bag safeBag, password string) privatekey.PrivateKey {
+	if curve.IsOidEqual(bag.id, _keyBagOid) {
+		return privatekey.FromDer(utils.ByteStringFromHex(bag.value.Raw))
+	}
+	fields := sequenceFields(bag.value.Raw, "EncryptedPrivateKeyInfo", 2)
+	plaintext := decrypt(fields[0], utils.ByteStringFromHex(fields[1].Content), password)
+	return privatekey.FromDer(plaintext)
+}
+
+func decryptEncryptedData(content []byte, password string) []byte {
+	fields := sequenceFields(utils.HexFromByteString(content), "EncryptedData", 2)
+	encryptedContentInfo := sequenceFields(fields[1].Raw, "EncryptedContentInfo", 3)
+
+	ciphertext := ""
+	switch encryptedContentInfo[2].Tag {
+	case _implicit0PrimitiveTag:
+		ciphertext = encryptedContentInfo[2].Content
+	case _explicit0Tag: // BER encoders may send the ciphertext in OCTET STRING chunks
+		for _, chunk := range utils.ParseElements(encryptedContentInfo[2].Content) {
+			ciphertext += chunk.Content
+		}
+	default:
+		panic("PKCS#12 EncryptedData is missing its encrypted content")
+	}
+	return decrypt(encryptedContentInfo[1], utils.ByteStringFromHex(ciphertext), password)
+}
+
+func verifyMac(macData utils.Element, authSafe []byte, password string) {
+	fields := sequenceFields(macData.Raw, "MacData", 2)
+	digestInfo := sequenceFields(fields[0].Raw, "MAC DigestInfo", 2)
+	digestAlgorithm := utils.ParseOid(sequenceFields(digestInfo[0].Raw, "MAC digest algorithm", 1)[0].Content)
+	hf, ok := _macFuncs[oidKey(digestAlgorithm)]
+	if !ok {
+		panic(fmt.Sprintf("Unsupported PKCS#12 MAC digest algorithm %v", digestAlgorithm))
+	}
+	iterations := 1
+	if len(fields) > 2 {
+		iterations = int(utils.ParseInteger(fields[2].Content).Int64())
+	}
+
+	salt := utils.ByteStringFromHex(fields[1].Content)
+	macKey := pkcs12Kdf(hf, bmpString(password), salt, _macKeyId, iterations, hf().Size())
+	mac := hmac.New(hf, macKey)
+	mac.Write(authSafe)
+	if !hmac.Equal(mac.Sum(nil), utils.ByteStringFromHex(digestInfo[1].Content)) {
+		panic("PKCS#12 MAC verification failed: wrong password or corrupted file")
+	}
+}
+
+// parseContentInfo returns the content type and the bytes held by its [0] content, which
+// is the OCTET STRING payload for data and the inner structure for anything else
+func parseContentInfo(contentInfo utils.Element) ([]int64, []byte) {
+	fields := sequenceFields(contentInfo.Raw, "ContentInfo", 2)
+	contentType := utils.ParseOid(fields[0].Content)
+	if curve.IsOidEqual(contentType, _dataOid) {
+		return contentType, utils.ByteStringFromHex(explicitOctetString(fields[1]))
+	}
+	return contentType, utils.ByteStringFromHex(utils.ParseElements(fields[1].Content)[0].Raw)
+}
+
+func explicitOctetString(element utils.Element) string {
+	inner := utils.ParseElements(element.Content)
+	if element.Tag != _explicit0Tag || len(inner) != 1 || inner[0].Tag != _octetStringTag {
+		panic("PKCS#12 value should be an OCTET STRING explicitly tagged [0]")
+	}
+	return inner[0].Content
+}
+
+// sequenceFields parses hexadecimal as a single SEQUENCE with at least minimum fields
+func sequenceFields(hexadecimal string, name string, minimum int) []utils.Element {
+	elements := utils.ParseElements(hexadecimal)
+	if len(elements) != 1 || elements[0].Tag != _sequenceTag {
+		panic(fmt.Sprintf("PKCS#12 %v should be a SEQUENCE", name))
+	}
+	fields := utils.ParseElements(elements[0].Content)
+	if len(fields) < minimum {
+		panic(fmt.Sprintf("PKCS#12 %v is missing fields", name))
+	}
+	return fields
+}
+
+func encodeContentInfo(contentType []int64, content string) string {
+	return utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Object, contentType),
+		utils.EncodeTag(_explicit0Tag, content),
+	)
+}
+
+func encodeSafeBag(id []int64, value string, attributes string) string {
+	fields := []string{
+		utils.EncodePrimitive(utils.Object, id),
+		utils.EncodeTag(_explicit0Tag, value),
+	}
+	if attributes != "" {
+		fields = append(fields, utils.EncodePrimitive(utils.Set, attributes))
+	}
+	return utils.EncodeConstructed(fields...)
+}
+
+func encodeAttribute(oid []int64, value string) string {
+	return utils.EncodeConstructed(
+		utils.EncodePrimitive(utils.Object, oid),
+		utils.EncodePrimitive(utils.Set, value),
+	)
+}
+
+func oidKey(oid []int64) string {
+	return fmt.Sprint(oid)
+}
+
+const _defaultIterations = 2048
+
+const (
+	_sequenceTag           = "30"
+	_octetStringTag        = "04"
+	_explicit0Tag          = "a0"
+	_implicit0PrimitiveTag = "80"
+)
+
+var _dataOid = []int64{1, 2, 840, 113549, 1, 7, 1}
+var _encryptedDataOid = []int64{1, 2, 840, 113549, 1, 7, 6}
+
+var _keyBagOid = []int64{1, 2, 840, 113549, 1, 12, 10, 1, 1}
+var _shroudedKeyBagOid = []int64{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+var _certBagOid = []int64{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+var _x509CertificateOid = []int64{1, 2, 840, 113549, 1, 9, 22, 1}
+var _localKeyIdOid = []int64{1, 2, 840, 113549, 1, 9, 21}
+
+var _sha1Oid = []int64{1, 3, 14, 3, 2, 26}
+var _sha256Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 1}
+var _sha384Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 2}
+var _sha512Oid = []int64{2, 16, 840, 1, 101, 3, 4, 2, 3}
+
+var _macFuncs = map[string]utils.HashFunc{
+	oidKey(_sha1Oid):   sha1.New,
+	oidKey(_sha256Oid): utils.Sha256,
+	oidKey(_sha384Oid): utils.Sha384,
+	oidKey(_sha512Oid): utils.Sha512,
+}
@@ -5,16 +5,71 @@ import (
 	"math/big"
 )
 
+// Point is an affine point (X, Y) of a curve, or its point at infinity when Infinity is
+// set. Points in projective coordinates are Jacobians.
 type Point struct {
+	X        *big.Int
+	Y        *big.Int
+	Infinity bool
+}
+
+// AtInfinity returns the point at infinity, with zero coordinates so it can still be
+// printed or encoded
+func AtInfinity() Point {
+	return Point{X: big.NewInt(0), Y: big.NewInt(0), Infinity: true}
+}
+
+func (obj Point) IsAtInfinity() bool {
+	return obj.Infinity
+}
+
+// ToJacobian returns the point with Z = 1, or with Z = 0 at infinity
+func (obj Point) ToJacobian() Jacobian {
+	if obj.Infinity {
+		return JacobianAtInfinity()
+	}
+	return Jacobian{X: new(big.Int).Set(obj.X), Y: new(big.Int).Set(obj.Y), Z: big.NewInt(1)}
+}
+
+func (obj Point) String() string {
+	if obj.Infinity {
+		return "(infinity)"
+	}
+	return fmt.Sprintf("(%s, %s)", obj.X.String(), obj.Y.String())
+}
+
+// Jacobian is a point in Jacobian coordinates, (X/Z^2, Y/Z^3) in affine ones, and the
+// point at infinity exactly when Z = 0
+type Jacobian struct {
 	X *big.Int
 	Y *big.Int
 	Z *big.Int
 }
 
-func (obj Point) IsAtInfinity() bool {
-	return obj.Y.Cmp(big.NewInt(0)) == 0
+// JacobianAtInfinity returns the point at infinity (1, 1, 0)
+func JacobianAtInfinity() Jacobian {
+	return Jacobian{X: big.NewInt(1), Y: big.NewInt(1), Z: big.NewInt(0)}
 }
 
-func (obj Point) String() string {
-	return fmt.Sprintf("(%s, %s, %s)", obj.X.String(), obj.Y.String(), obj.Z.String())
+func (obj Jacobian) IsAtInfinity() bool {
+	return obj.Z.Sign() == 0
+}
+
+// ToAffine converts the point to affine coordinates, over the field of prime P
+func (obj Jacobian) ToAffine(P *big.Int) Point {
+	if obj.IsAtInfinity() {
+		return AtInfinity()
+	}
+	zInv := new(big.Int).ModInverse(obj.Z, P)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+	zInv2.Mod(zInv2, P)
+	x := new(big.Int).Mul(obj.X, zInv2)
+	x.Mod(x, P)
+	y := new(big.Int).Mul(obj.Y, zInv2)
+	y.Mul(y, zInv).Mod(y, P)
+	return Point{X: x, Y: y}
+}
+
+func (obj Jacobian) String() string {
+	return fmt.Sprintf("(%s : %s : %s)", obj.X.String(), obj.Y.String(), obj.Z.String())
 }
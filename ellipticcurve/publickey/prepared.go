@@ -0,0 +1,38 @@
+package publickey
+
+import (
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+)
+
+// PreparedPublicKey is a public key with the precomputed multiples of its point and
+// the result of its subgroup check, to verify many signatures against it with
+// ecdsa.VerifyPrepared
+type PreparedPublicKey struct {
+	publicKey  PublicKey
+	prepared   ecmath.PreparedPoint
+	inSubgroup bool
+}
+
+// Precompute prepares the key for repeated verifications: its wNAF table, or the
+// tables of both GLV endomorphism images on secp256k1
+func (obj PublicKey) Precompute() PreparedPublicKey {
+	return PreparedPublicKey{
+		publicKey:  obj,
+		prepared:   obj.Curve.Prepare(obj.Point),
+		inSubgroup: obj.Curve.InSubgroup(obj.Point),
+	}
+}
+
+func (obj PreparedPublicKey) PublicKey() PublicKey {
+	return obj.publicKey
+}
+
+// Prepared returns the precomputed multiples of the point, for CurveFp.MultiplyGeneratorAndAdd
+func (obj PreparedPublicKey) Prepared() ecmath.PreparedPoint {
+	return obj.prepared
+}
+
+// InSubgroup returns whether the point is on the curve and in the subgroup of G
+func (obj PreparedPublicKey) InSubgroup() bool {
+	return obj.inSubgroup
+}
@@ -1,11 +1,12 @@
 package publickey
 
 import (
+	"encoding/base64"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
-	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
 )
@@ -40,7 +41,7 @@ func (obj PublicKey) ToDer() []byte {
 	hexadecimal := utils.EncodeConstructed(
 		utils.EncodeConstructed(
 			utils.EncodePrimitive(utils.Object, _ecdsaPublicKeyOid),
-			utils.EncodePrimitive(utils.Object, obj.Curve.Oid),
+			obj.Curve.EncodeParameters(),
 		),
 		utils.EncodePrimitive(utils.BitString, obj.ToString(true)),
 	)
@@ -52,6 +53,36 @@ func (obj PublicKey) ToPem() string {
 	return utils.CreatePem(utils.Base64FromByteString(der), toPemTemplate)
 }
 
+// SshKeyType returns the RFC 5656 key type name, e.g. "ecdsa-sha2-nistp256"
+func (obj PublicKey) SshKeyType() string {
+	return _sshKeyTypePrefix + sshCurveName(obj.Curve)
+}
+
+// SshHash returns the hash RFC 5656 §6.2.1 pairs with the key's curve size for SSH signatures
+func (obj PublicKey) SshHash() utils.HashFunc {
+	switch {
+	case obj.Curve.NBitLength <= 256:
+		return utils.Sha256
+	case obj.Curve.NBitLength <= 384:
+		return utils.Sha384
+	default:
+		return utils.Sha512
+	}
+}
+
+// ToSshBlob encodes the key in the SSH public key wire format (RFC 5656 §3.1)
+func (obj PublicKey) ToSshBlob() []byte {
+	curveName := sshCurveName(obj.Curve)
+	blob := utils.SshString([]byte(obj.SshKeyType()))
+	blob = append(blob, utils.SshString([]byte(curveName))...)
+	return append(blob, utils.SshString(utils.ByteStringFromHex("04"+obj.ToString(false)))...)
+}
+
+// ToSsh returns the key in the OpenSSH authorized_keys format, e.g. "ecdsa-sha2-nistp256 AAAA..."
+func (obj PublicKey) ToSsh() string {
+	return obj.SshKeyType() + " " + utils.Base64FromByteString(obj.ToSshBlob())
+}
+
 func FromPem(pem string) PublicKey {
 	publicKeyPem := utils.GetPemContent(pem, fromPemTemplate)
 	return FromDer(utils.ByteStringFromBase64(publicKeyPem))
@@ -63,7 +94,6 @@ func FromDer(data []byte) PublicKey {
 	curveData := parsed[0]
 	pointString := parsed[1].(string)
 	publicKeyOid := curveData.([]interface{})[0].([]int64)
-	curveOid := curveData.([]interface{})[1].([]int64)
 
 	if !curve.IsOidEqual(publicKeyOid, _ecdsaPublicKeyOid) {
 		panic(fmt.Sprintf(
@@ -72,7 +102,7 @@ func FromDer(data []byte) PublicKey {
 			publicKeyOid,
 		))
 	}
-	c := curve.GetByOid(curveOid)
+	c := curve.FromParameters(curveData.([]interface{})[1])
 	return FromString(pointString, c, true)
 }
 
@@ -85,7 +115,11 @@ func FromString(str string, c curve.CurveFp, validatePoint bool) PublicKey {
 	xs := str[:baseLength]
 	ys := str[baseLength:]
 
-	publicPoint := point.Point{X: utils.IntFromHex(xs), Y: utils.IntFromHex(ys), Z: big.NewInt(0)}
+	publicPoint := point.Point{X: utils.IntFromHex(xs), Y: utils.IntFromHex(ys)}
+	if publicPoint.X.Sign() == 0 && publicPoint.Y.Sign() == 0 {
+		// the zero coordinates ToString gives the point at infinity
+		publicPoint = point.AtInfinity()
+	}
 
 	publicKey := PublicKey{
 		Point: publicPoint,
@@ -106,7 +140,7 @@ func FromString(str string, c curve.CurveFp, validatePoint bool) PublicKey {
 			c.Name,
 		))
 	}
-	if !ecmath.Multiply(publicPoint, c.N, c.N, c.A, c.P).IsAtInfinity() {
+	if !c.InSubgroup(publicPoint) {
 		panic(fmt.Sprintf(
 			"Point (%v,%v) * %v.N is not at infinity",
 			publicPoint.X,
@@ -131,14 +165,71 @@ func FromCompressed(str string, c ...curve.CurveFp) PublicKey {
 	x := utils.IntFromHex(xHex)
 	y := curv.Y(x, parityTag == _evenTag)
 	return PublicKey{
-		Point: point.Point{X: x, Y: y, Z: big.NewInt(0)},
+		Point: point.Point{X: x, Y: y},
 		Curve: curv,
 	}
 }
 
+// FromSshBlob parses a key in the SSH public key wire format (RFC 5656 §3.1)
+func FromSshBlob(blob []byte) PublicKey {
+	reader := utils.SshReader{Data: blob}
+	keyType := string(reader.ReadString())
+	curveName := string(reader.ReadString())
+	pointString := utils.HexFromByteString(reader.ReadString())
+	if !reader.Empty() {
+		panic("trailing bytes in SSH public key")
+	}
+	if keyType != _sshKeyTypePrefix+curveName {
+		panic(fmt.Sprintf("SSH key type %v doesn't match curve %v", keyType, curveName))
+	}
+	c, ok := _sshCurves[curveName]
+	if !ok {
+		panic(fmt.Sprintf("Unknown SSH curve %v", curveName))
+	}
+	if len(pointString) != 2+4*c.Length() || pointString[:2] != "04" {
+		panic("SSH public key point should be uncompressed")
+	}
+	return FromString(pointString[2:], c, true)
+}
+
+// FromSsh parses a key in the OpenSSH authorized_keys format. A trailing comment is ignored.
+func FromSsh(str string) PublicKey {
+	fields := strings.Fields(str)
+	if len(fields) < 2 {
+		panic("SSH public key should be formatted as '<key type> <base64 blob> [comment]'")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		panic(fmt.Sprintf("Invalid base64 in SSH public key: %v", err))
+	}
+	publicKey := FromSshBlob(blob)
+	if fields[0] != publicKey.SshKeyType() {
+		panic(fmt.Sprintf("SSH key type %v doesn't match the encoded key", fields[0]))
+	}
+	return publicKey
+}
+
+func sshCurveName(c curve.CurveFp) string {
+	for name, sshCurve := range _sshCurves {
+		if curve.IsOidEqual(sshCurve.Oid, c.Oid) {
+			return name
+		}
+	}
+	panic(fmt.Sprintf("Curve %v has no SSH key type", c.Name))
+}
+
 const _evenTag = "02"
 const _oddTag = "03"
 
+const _sshKeyTypePrefix = "ecdsa-sha2-"
+
+// _sshCurves maps RFC 5656 curve identifiers to the curves they name
+var _sshCurves = map[string]curve.CurveFp{
+	"nistp256": curve.Prime256v1,
+	"nistp384": curve.Secp384r1,
+	"nistp521": curve.Secp521r1,
+}
+
 var _ecdsaPublicKeyOid = []int64{1, 2, 840, 10045, 2, 1}
 
 const toPemTemplate = `
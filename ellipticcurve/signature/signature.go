@@ -65,6 +65,22 @@ func FromBase64(str string, recoveryByte ...bool) Signature {
 	return FromDer(der, recoveryByte...)
 }
 
+// ToSsh encodes the signature as an RFC 5656 ecdsa_signature_blob (mpint r, mpint s)
+func (obj Signature) ToSsh() []byte {
+	return append(utils.SshMpint(&obj.R), utils.SshMpint(&obj.S)...)
+}
+
+// FromSsh parses an RFC 5656 ecdsa_signature_blob (mpint r, mpint s)
+func FromSsh(data []byte) Signature {
+	reader := utils.SshReader{Data: data}
+	r := reader.ReadMpint()
+	s := reader.ReadMpint()
+	if !reader.Empty() {
+		panic("trailing bytes in SSH signature")
+	}
+	return New(*r, *s)
+}
+
 func (obj Signature) _ToString() string {
 	return utils.EncodeConstructed(
 		utils.EncodePrimitive(utils.Integer, &obj.R),
@@ -0,0 +1,129 @@
+//
+// SM2 digital signatures (GB/T 32918.2-2016, GM/T 0003.2-2012) with the SM3 hash
+//
+// https://datatracker.ietf.org/doc/html/draft-shen-sm2-ecdsa-02
+//
+
+package sm2
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// DefaultId is the distinguishing identifier GB/T 35276-2017 specifies when the parties
+// haven't agreed on another one. Note that `openssl pkeyutl` uses an empty identity unless
+// given -pkeyopt distid:1234567812345678.
+const DefaultId = "1234567812345678"
+
+// Sign creates an SM2 signature over message. The signer's identity defaults to DefaultId.
+// Keys are usually on curve.Sm2p256v1, although any curve works.
+func Sign(message string, privateKey *privatekey.PrivateKey, id ...string) signature.Signature {
+	c := privateKey.Curve
+	// GB/T 32918.1 §6.1: d = N - 1 leaves 1 + d without an inverse
+	if privateKey.Secret.Sign() <= 0 || privateKey.Secret.Cmp(new(big.Int).Sub(c.N, big.NewInt(1))) >= 0 {
+		panic(fmt.Sprintf("The SM2 private key secret should be between 1 and %v.N - 2", c.Name))
+	}
+	publicKey := privateKey.PublicKey()
+	digest := digest(message, &publicKey, id...)
+	e := new(big.Int).SetBytes(digest)
+
+	// s = (1 + d)^-1 * (k - r*d) mod n
+	dPlus1Inv := ecmath.Inv(new(big.Int).Add(privateKey.Secret, big.NewInt(1)), c.N)
+	nextK := utils.Rfc6979(digest, privateKey.Secret, c.N, c.NBitLength, utils.Sm3)
+	for {
+		k := nextK()
+		randSignPoint := c.MultiplyGenerator(k)
+
+		r := new(big.Int).Add(e, randSignPoint.X)
+		r.Mod(r, c.N)
+		if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(c.N) == 0 {
+			continue
+		}
+
+		s := new(big.Int).Mul(r, privateKey.Secret)
+		s.Sub(k, s)
+		s.Mul(s, dPlus1Inv)
+		s.Mod(s, c.N)
+		if s.Sign() == 0 {
+			continue
+		}
+		return signature.New(*r, *s)
+	}
+}
+
+// Verify checks an SM2 signature over message, made by the owner of publicKey under the
+// given identity (DefaultId when left out)
+func Verify(message string, sig signature.Signature, publicKey *publickey.PublicKey, id ...string) bool {
+	c := publicKey.Curve
+	r := &sig.R
+	s := &sig.S
+
+	one := big.NewInt(1)
+	nMinus1 := new(big.Int).Sub(c.N, one)
+	if r.Cmp(one) < 0 || r.Cmp(nMinus1) > 0 {
+		return false
+	}
+	if s.Cmp(one) < 0 || s.Cmp(nMinus1) > 0 {
+		return false
+	}
+	if !c.InSubgroup(publicKey.Point) {
+		return false
+	}
+
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, c.N)
+	if t.Sign() == 0 {
+		return false
+	}
+
+	// (x1, y1) = s*G + t*P_A
+	v := c.MultiplyAndAdd(c.G, s, publicKey.Point, t)
+	if v.IsAtInfinity() {
+		return false
+	}
+	e := new(big.Int).SetBytes(digest(message, publicKey, id...))
+	expected := new(big.Int).Add(e, v.X)
+	return expected.Mod(expected, c.N).Cmp(r) == 0
+}
+
+// Za computes the user identity hash SM3(ENTL || ID || a || b || xG || yG || xA || yA),
+// which SM2 prepends to the message before hashing (GB/T 32918.2-2016 §5.5)
+func Za(publicKey *publickey.PublicKey, id ...string) []byte {
+	identity := DefaultId
+	if len(id) > 0 {
+		identity = id[0]
+	}
+	entl := 8 * len(identity)
+	if entl > 0xffff {
+		panic(fmt.Sprintf("SM2 identities are limited to 8191 bytes, but %v were given", len(identity)))
+	}
+
+	c := publicKey.Curve
+	h := utils.Sm3()
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write([]byte(identity))
+	for _, value := range []*big.Int{c.A, c.B, c.G.X, c.G.Y, publicKey.Point.X, publicKey.Point.Y} {
+		h.Write(fieldBytes(value, c))
+	}
+	return h.Sum(nil)
+}
+
+// digest computes e = SM3(Z_A || M)
+func digest(message string, publicKey *publickey.PublicKey, id ...string) []byte {
+	h := utils.Sm3()
+	h.Write(Za(publicKey, id...))
+	h.Write([]byte(message))
+	return h.Sum(nil)
+}
+
+func fieldBytes(value *big.Int, c curve.CurveFp) []byte {
+	return value.FillBytes(make([]byte, c.Length()))
+}
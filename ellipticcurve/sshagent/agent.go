@@ -0,0 +1,402 @@
+//
+// SSH agent protocol server
+//
+// https://datatracker.ietf.org/doc/html/draft-miller-ssh-agent
+//
+
+package sshagent
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Identity is a key held by the agent, as reported to clients
+type Identity struct {
+	PublicKey publickey.PublicKey
+	Comment   string
+}
+
+type entry struct {
+	privateKey privatekey.PrivateKey
+	publicKey  publickey.PublicKey
+	blob       string
+	comment    string
+	expiresAt  time.Time
+}
+
+// Agent holds private keys in memory and signs with them on behalf of ssh-agent clients.
+// Key material never leaves the agent: clients only see public keys and signatures.
+type Agent struct {
+	mutex      sync.Mutex
+	entries    []entry
+	locked     bool
+	passphrase []byte
+}
+
+func New() *Agent {
+	return &Agent{}
+}
+
+// Add loads privateKey into the agent. A non-zero lifetime makes the agent forget the key after it.
+// Adding a key that is already loaded replaces its comment and lifetime.
+func (obj *Agent) Add(privateKey privatekey.PrivateKey, comment string, lifetime ...time.Duration) error {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if obj.locked {
+		return errors.New("agent is locked")
+	}
+	publicKey := privateKey.PublicKey()
+	newEntry := entry{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		blob:       string(publicKey.ToSshBlob()),
+		comment:    comment,
+	}
+	if len(lifetime) > 0 && lifetime[0] > 0 {
+		newEntry.expiresAt = time.Now().Add(lifetime[0])
+	}
+	for i, current := range obj.entries {
+		if current.blob == newEntry.blob {
+			obj.entries[i] = newEntry
+			return nil
+		}
+	}
+	obj.entries = append(obj.entries, newEntry)
+	return nil
+}
+
+// Remove drops the key matching publicKey, reporting whether it was loaded
+func (obj *Agent) Remove(publicKey publickey.PublicKey) bool {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if obj.locked {
+		return false
+	}
+	return obj.remove(string(publicKey.ToSshBlob()))
+}
+
+// RemoveAll drops every key, reporting false when the agent is locked
+func (obj *Agent) RemoveAll() bool {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if obj.locked {
+		return false
+	}
+	obj.entries = nil
+	return true
+}
+
+// List returns the loaded identities. A locked agent reports none.
+func (obj *Agent) List() []Identity {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	obj.expire()
+	if obj.locked {
+		return nil
+	}
+	identities := make([]Identity, 0, len(obj.entries))
+	for _, current := range obj.entries {
+		identities = append(identities, Identity{PublicKey: current.publicKey, Comment: current.comment})
+	}
+	return identities
+}
+
+// Lock hides every key until Unlock is called with the same passphrase
+func (obj *Agent) Lock(passphrase []byte) error {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if obj.locked {
+		return errors.New("agent is already locked")
+	}
+	obj.locked = true
+	obj.passphrase = append([]byte{}, passphrase...)
+	return nil
+}
+
+func (obj *Agent) Unlock(passphrase []byte) error {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if !obj.locked {
+		return errors.New("agent is not locked")
+	}
+	if subtle.ConstantTimeCompare(obj.passphrase, passphrase) != 1 {
+		return errors.New("incorrect passphrase")
+	}
+	obj.locked = false
+	obj.passphrase = nil
+	return nil
+}
+
+// Serve accepts connections on listener and answers agent requests on each of them until the
+// listener is closed
+func (obj *Agent) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			obj.ServeConn(conn)
+		}()
+	}
+}
+
+// ListenAndServe serves the agent on a Unix socket at path, readable only by the current user,
+// the way ssh-agent exposes SSH_AUTH_SOCK. The socket is created in a new 0700 directory next
+// to path and made 0600 before it's linked at path, so no other user can connect in between.
+func (obj *Agent) ListenAndServe(path string) error {
+	listener, err := listenPrivate(path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+	defer listener.Close()
+	return obj.Serve(listener)
+}
+
+// listenPrivate listens on a 0600 Unix socket at path, failing when path exists
+func listenPrivate(path string) (*net.UnixListener, error) {
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".sshagent-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	private := filepath.Join(dir, "agent.sock")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: private, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// the socket outlives its temporary name, and is removed at path instead
+	listener.SetUnlinkOnClose(false)
+	if err := os.Chmod(private, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	if err := os.Link(private, path); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// ServeConn answers agent requests read from conn until it is closed
+func (obj *Agent) ServeConn(conn io.ReadWriter) error {
+	for {
+		request, err := readMessage(conn)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := writeMessage(conn, obj.handle(request)); err != nil {
+			return err
+		}
+	}
+}
+
+// handle answers one request. Malformed requests get a failure reply instead of bringing
+// the connection down.
+func (obj *Agent) handle(request []byte) (response []byte) {
+	defer func() {
+		if recover() != nil {
+			response = []byte{agentFailure}
+		}
+	}()
+
+	reader := utils.SshReader{Data: request}
+	switch reader.ReadUint8() {
+	case agentcRequestIdentities:
+		identities := obj.List()
+		response = append([]byte{agentIdentitiesAnswer}, utils.SshUint32(uint32(len(identities)))...)
+		for _, identity := range identities {
+			response = append(response, utils.SshString(identity.PublicKey.ToSshBlob())...)
+			response = append(response, utils.SshString([]byte(identity.Comment))...)
+		}
+		return response
+	case agentcSignRequest:
+		blob := reader.ReadString()
+		data := reader.ReadString()
+		reader.ReadUint32() // flags only apply to RSA keys
+		signature, ok := obj.sign(string(blob), data)
+		if !ok {
+			return []byte{agentFailure}
+		}
+		return append([]byte{agentSignResponse}, utils.SshString(signature)...)
+	case agentcAddIdentity, agentcAddIdConstrained:
+		privateKey, comment := readPrivateKey(&reader)
+		var lifetime time.Duration
+		for !reader.Empty() {
+			switch reader.ReadUint8() {
+			case agentConstrainLifetime:
+				lifetime = time.Duration(reader.ReadUint32()) * time.Second
+			default:
+				return []byte{agentFailure}
+			}
+		}
+		return status(obj.Add(privateKey, comment, lifetime) == nil)
+	case agentcRemoveIdentity:
+		publicKey := publickey.FromSshBlob(reader.ReadString())
+		return status(obj.Remove(publicKey))
+	case agentcRemoveAllIdentities:
+		return status(obj.RemoveAll())
+	case agentcLock:
+		return status(obj.Lock(reader.ReadString()) == nil)
+	case agentcUnlock:
+		return status(obj.Unlock(reader.ReadString()) == nil)
+	default:
+		return []byte{agentFailure}
+	}
+}
+
+// sign returns the SSH signature blob, string(key type) || string(ecdsa_signature_blob),
+// of data made by the key whose public blob is given
+func (obj *Agent) sign(blob string, data []byte) ([]byte, bool) {
+	obj.mutex.Lock()
+	obj.expire()
+	var signer entry
+	found := false
+	for _, current := range obj.entries {
+		if !obj.locked && current.blob == blob {
+			signer = current
+			found = true
+			break
+		}
+	}
+	obj.mutex.Unlock()
+
+	if !found {
+		return nil, false
+	}
+	privateKey, publicKey := signer.privateKey, signer.publicKey
+	sig := ecdsa.Sign(string(data), &privateKey, publicKey.SshHash())
+	signature := utils.SshString([]byte(publicKey.SshKeyType()))
+	return append(signature, utils.SshString(sig.ToSsh())...), true
+}
+
+func (obj *Agent) remove(blob string) bool {
+	for i, current := range obj.entries {
+		if current.blob == blob {
+			obj.entries = append(obj.entries[:i], obj.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// expire drops keys whose lifetime has passed. The caller must hold the mutex.
+func (obj *Agent) expire() {
+	now := time.Now()
+	kept := obj.entries[:0]
+	for _, current := range obj.entries {
+		if current.expiresAt.IsZero() || now.Before(current.expiresAt) {
+			kept = append(kept, current)
+		}
+	}
+	obj.entries = kept
+}
+
+// readPrivateKey parses an RFC 5656 ECDSA private key as sent in add identity requests:
+// string(key type) || string(curve name) || string(Q) || mpint(d) || string(comment)
+func readPrivateKey(reader *utils.SshReader) (privatekey.PrivateKey, string) {
+	keyType := reader.ReadString()
+	curveName := reader.ReadString()
+	point := reader.ReadString()
+	secret := reader.ReadMpint()
+	comment := string(reader.ReadString())
+
+	blob := append(utils.SshString(keyType), utils.SshString(curveName)...)
+	publicKey := publickey.FromSshBlob(append(blob, utils.SshString(point)...))
+	if secret.Sign() <= 0 || secret.Cmp(publicKey.Curve.N) >= 0 {
+		panic("SSH private key is out of range")
+	}
+	privateKey := privatekey.New(publicKey.Curve, secret)
+	if privateKey.PublicKey().ToString(false) != publicKey.ToString(false) {
+		panic("SSH private key doesn't match its public key")
+	}
+	return privateKey, comment
+}
+
+// writePrivateKey is the inverse of readPrivateKey
+func writePrivateKey(privateKey privatekey.PrivateKey, comment string) []byte {
+	publicKey := privateKey.PublicKey()
+	reader := utils.SshReader{Data: publicKey.ToSshBlob()}
+	data := utils.SshString(reader.ReadString())
+	data = append(data, utils.SshString(reader.ReadString())...)
+	data = append(data, utils.SshString(reader.ReadString())...)
+	data = append(data, utils.SshMpint(privateKey.Secret)...)
+	return append(data, utils.SshString([]byte(comment))...)
+}
+
+func status(ok bool) []byte {
+	if ok {
+		return []byte{agentSuccess}
+	}
+	return []byte{agentFailure}
+}
+
+// readMessage reads one uint32 length prefixed agent message
+func readMessage(conn io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(conn, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length == 0 || length > maxMessageLength {
+		return nil, fmt.Errorf("invalid agent message length %v", length)
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(conn, message); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+func writeMessage(conn io.Writer, message []byte) error {
+	_, err := conn.Write(utils.SshString(message))
+	return err
+}
+
+const maxMessageLength = 256 * 1024
+
+const (
+	agentFailure              = 5
+	agentSuccess              = 6
+	agentcRequestIdentities   = 11
+	agentIdentitiesAnswer     = 12
+	agentcSignRequest         = 13
+	agentSignResponse         = 14
+	agentcAddIdentity         = 17
+	agentcRemoveIdentity      = 18
+	agentcRemoveAllIdentities = 19
+	agentcLock                = 22
+	agentcUnlock              = 23
+	agentcAddIdConstrained    = 25
+
+	agentConstrainLifetime = 1
+)
@@ -0,0 +1,147 @@
+package sshagent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// Client speaks the agent protocol over conn, e.g. a net.Conn dialed to SSH_AUTH_SOCK.
+// It works with this package's Agent as well as OpenSSH's ssh-agent.
+type Client struct {
+	mutex sync.Mutex
+	conn  io.ReadWriter
+}
+
+func NewClient(conn io.ReadWriter) *Client {
+	return &Client{conn: conn}
+}
+
+// List returns the identities the agent holds. Keys of types this library can't
+// represent (e.g. ssh-ed25519) are skipped.
+func (obj *Client) List() ([]Identity, error) {
+	response, err := obj.call([]byte{agentcRequestIdentities})
+	if err != nil {
+		return nil, err
+	}
+	if response[0] != agentIdentitiesAnswer {
+		return nil, errors.New("agent refused to list identities")
+	}
+	return parseIdentities(response[1:])
+}
+
+// Sign asks the agent to sign data with the key matching publicKey
+func (obj *Client) Sign(publicKey publickey.PublicKey, data []byte) (signature.Signature, error) {
+	request := append([]byte{agentcSignRequest}, utils.SshString(publicKey.ToSshBlob())...)
+	request = append(request, utils.SshString(data)...)
+	request = append(request, utils.SshUint32(0)...)
+	response, err := obj.call(request)
+	if err != nil {
+		return signature.Signature{}, err
+	}
+	if response[0] != agentSignResponse {
+		return signature.Signature{}, errors.New("agent refused to sign")
+	}
+	return parseSignature(response[1:], publicKey)
+}
+
+// Add sends privateKey to the agent. A non-zero lifetime is passed as a lifetime constraint.
+func (obj *Client) Add(privateKey privatekey.PrivateKey, comment string, lifetime ...time.Duration) error {
+	request := append([]byte{agentcAddIdentity}, writePrivateKey(privateKey, comment)...)
+	if len(lifetime) > 0 && lifetime[0] > 0 {
+		request[0] = agentcAddIdConstrained
+		request = append(request, agentConstrainLifetime)
+		request = append(request, utils.SshUint32(uint32(lifetime[0]/time.Second))...)
+	}
+	return obj.expectSuccess(request, "add identity")
+}
+
+func (obj *Client) Remove(publicKey publickey.PublicKey) error {
+	request := append([]byte{agentcRemoveIdentity}, utils.SshString(publicKey.ToSshBlob())...)
+	return obj.expectSuccess(request, "remove identity")
+}
+
+func (obj *Client) RemoveAll() error {
+	return obj.expectSuccess([]byte{agentcRemoveAllIdentities}, "remove all identities")
+}
+
+func (obj *Client) Lock(passphrase []byte) error {
+	return obj.expectSuccess(append([]byte{agentcLock}, utils.SshString(passphrase)...), "lock")
+}
+
+func (obj *Client) Unlock(passphrase []byte) error {
+	return obj.expectSuccess(append([]byte{agentcUnlock}, utils.SshString(passphrase)...), "unlock")
+}
+
+func (obj *Client) expectSuccess(request []byte, operation string) error {
+	response, err := obj.call(request)
+	if err != nil {
+		return err
+	}
+	if response[0] != agentSuccess {
+		return fmt.Errorf("agent refused to %v", operation)
+	}
+	return nil
+}
+
+func (obj *Client) call(request []byte) ([]byte, error) {
+	obj.mutex.Lock()
+	defer obj.mutex.Unlock()
+
+	if err := writeMessage(obj.conn, request); err != nil {
+		return nil, err
+	}
+	return readMessage(obj.conn)
+}
+
+func parseIdentities(data []byte) (identities []Identity, err error) {
+	defer func() {
+		if recover() != nil {
+			err = errors.New("malformed identities answer")
+		}
+	}()
+
+	reader := utils.SshReader{Data: data}
+	count := reader.ReadUint32()
+	for i := uint32(0); i < count; i++ {
+		blob := reader.ReadString()
+		comment := string(reader.ReadString())
+		publicKey, ok := parsePublicKey(blob)
+		if ok {
+			identities = append(identities, Identity{PublicKey: publicKey, Comment: comment})
+		}
+	}
+	return identities, nil
+}
+
+func parsePublicKey(blob []byte) (publicKey publickey.PublicKey, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return publickey.FromSshBlob(blob), true
+}
+
+func parseSignature(data []byte, publicKey publickey.PublicKey) (sig signature.Signature, err error) {
+	defer func() {
+		if recover() != nil {
+			err = errors.New("malformed sign response")
+		}
+	}()
+
+	reader := utils.SshReader{Data: data}
+	sigReader := utils.SshReader{Data: reader.ReadString()}
+	keyType := string(sigReader.ReadString())
+	if keyType != publicKey.SshKeyType() {
+		return sig, fmt.Errorf("agent signed with %v instead of %v", keyType, publicKey.SshKeyType())
+	}
+	return signature.FromSsh(sigReader.ReadString()), nil
+}
@@ -0,0 +1,253 @@
+package sshsig
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+)
+
+// AllowedSigner is one entry of an ssh-keygen allowed_signers file:
+//
+// principals [options] keytype base64-key [comment]
+type AllowedSigner struct {
+	Principals    []string
+	Namespaces    []string
+	CertAuthority bool
+	ValidAfter    time.Time
+	ValidBefore   time.Time
+	PublicKey     publickey.PublicKey
+}
+
+type AllowedSigners []AllowedSigner
+
+// ParseAllowedSigners parses the contents of an allowed_signers file (see ssh-keygen(1)).
+// Blank lines and comments are skipped, as are entries whose key type this library cannot
+// represent (e.g. ssh-ed25519), so mixed files can be shared with OpenSSH.
+func ParseAllowedSigners(content string) AllowedSigners {
+	var signers AllowedSigners
+	for number, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := splitFields(line)
+		if len(fields) < 3 {
+			panic(fmt.Sprintf("allowed_signers line %v should have principals, a key type and a key", number+1))
+		}
+
+		signer := AllowedSigner{Principals: splitList(unquote(fields[0]))}
+		fields = fields[1:]
+		if !isKeyType(fields[0]) {
+			parseOptions(&signer, fields[0], number+1)
+			fields = fields[1:]
+		}
+		if len(fields) < 2 {
+			panic(fmt.Sprintf("allowed_signers line %v is missing its key", number+1))
+		}
+		if !strings.HasPrefix(fields[0], _ecdsaKeyTypePrefix) {
+			continue
+		}
+		signer.PublicKey = publickey.FromSsh(fields[0] + " " + fields[1])
+		signers = append(signers, signer)
+	}
+	return signers
+}
+
+// Verify checks that sig is a valid signature over message, within namespace, made by a key
+// trusted for principal at the current time
+func (obj AllowedSigners) Verify(message string, sig Signature, principal string, namespace string) bool {
+	now := time.Now()
+	for _, signer := range obj {
+		if !signer.Accepts(principal, namespace, now) {
+			continue
+		}
+		if Verify(message, sig, &signer.PublicKey, namespace) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindPrincipals returns the principals of every entry holding the key that made sig
+func (obj AllowedSigners) FindPrincipals(sig Signature) []string {
+	var principals []string
+	for _, signer := range obj {
+		if signer.CertAuthority {
+			continue
+		}
+		if sig.PublicKey.ToSsh() == signer.PublicKey.ToSsh() {
+			principals = append(principals, signer.Principals...)
+		}
+	}
+	return principals
+}
+
+// Accepts reports whether the entry trusts its key for principal and namespace at the given time.
+// Certificate authority entries never accept plain keys.
+func (obj AllowedSigner) Accepts(principal string, namespace string, at time.Time) bool {
+	if obj.CertAuthority {
+		return false
+	}
+	if !matchPatternList(principal, obj.Principals) {
+		return false
+	}
+	if obj.Namespaces != nil && !matchPatternList(namespace, obj.Namespaces) {
+		return false
+	}
+	if !obj.ValidAfter.IsZero() && at.Before(obj.ValidAfter) {
+		return false
+	}
+	if !obj.ValidBefore.IsZero() && !at.Before(obj.ValidBefore) {
+		return false
+	}
+	return true
+}
+
+func parseOptions(signer *AllowedSigner, options string, number int) {
+	for _, option := range splitList(options) {
+		name, value, hasValue := strings.Cut(option, "=")
+		value = unquote(value)
+		switch strings.ToLower(name) {
+		case "cert-authority":
+			signer.CertAuthority = true
+		case "namespaces":
+			signer.Namespaces = splitList(value)
+		case "valid-after":
+			signer.ValidAfter = parseTime(value, number)
+		case "valid-before":
+			signer.ValidBefore = parseTime(value, number)
+		default:
+			panic(fmt.Sprintf("Unknown option %v in allowed_signers line %v", name, number))
+		}
+		if !hasValue && name != "cert-authority" {
+			panic(fmt.Sprintf("Option %v in allowed_signers line %v requires a value", name, number))
+		}
+	}
+}
+
+// parseTime reads YYYYMMDD[HHMM[SS]] timestamps, in local time unless suffixed with Z
+func parseTime(value string, number int) time.Time {
+	location := time.Local
+	if strings.HasSuffix(value, "Z") || strings.HasSuffix(value, "z") {
+		location = time.UTC
+		value = value[:len(value)-1]
+	}
+	layouts := map[int]string{8: "20060102", 12: "200601021504", 14: "20060102150405"}
+	layout, ok := layouts[len(value)]
+	if !ok {
+		panic(fmt.Sprintf("Invalid time %v in allowed_signers line %v", value, number))
+	}
+	parsed, err := time.ParseInLocation(layout, value, location)
+	if err != nil {
+		panic(fmt.Sprintf("Invalid time %v in allowed_signers line %v", value, number))
+	}
+	return parsed
+}
+
+// splitFields splits line on whitespace that isn't inside double quotes
+func splitFields(line string) []string {
+	var fields []string
+	var current strings.Builder
+	quoted := false
+	for _, char := range line {
+		switch {
+		case char == '"':
+			quoted = !quoted
+			current.WriteRune(char)
+		case !quoted && (char == ' ' || char == '\t'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(char)
+		}
+	}
+	if quoted {
+		panic("Unterminated quote in allowed_signers")
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+// splitList splits a comma separated list, ignoring commas inside double quotes
+func splitList(list string) []string {
+	var items []string
+	start := 0
+	quoted := false
+	for i, char := range list {
+		switch {
+		case char == '"':
+			quoted = !quoted
+		case char == ',' && !quoted:
+			items = append(items, list[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, list[start:])
+}
+
+func unquote(str string) string {
+	if len(str) >= 2 && str[0] == '"' && str[len(str)-1] == '"' {
+		return str[1 : len(str)-1]
+	}
+	return str
+}
+
+func isKeyType(field string) bool {
+	for _, prefix := range []string{"ssh-", "ecdsa-", "sk-", "rsa-"} {
+		if strings.HasPrefix(field, prefix) && !strings.Contains(field, "=") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPatternList follows OpenSSH's match_pattern_list: patterns may use * and ?, and
+// a matching pattern prefixed with ! rejects the name outright
+func matchPatternList(name string, patterns []string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			if matchPattern(name, pattern[1:]) {
+				return false
+			}
+			continue
+		}
+		if matchPattern(name, pattern) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+func matchPattern(name string, pattern string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := 0; i <= len(name); i++ {
+				if matchPattern(name[i:], pattern[1:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(name) == 0 {
+				return false
+			}
+		default:
+			if len(name) == 0 || name[0] != pattern[0] {
+				return false
+			}
+		}
+		name = name[1:]
+		pattern = pattern[1:]
+	}
+	return len(name) == 0
+}
+
+const _ecdsaKeyTypePrefix = "ecdsa-sha2-"
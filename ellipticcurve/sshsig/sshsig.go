@@ -0,0 +1,163 @@
+//
+// SSH signatures (SSHSIG), as produced by `ssh-keygen -Y sign` and git commit signing
+//
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+//
+
+package sshsig
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+type Signature struct {
+	PublicKey     publickey.PublicKey
+	Namespace     string
+	HashAlgorithm string
+	Signature     signature.Signature
+}
+
+// Sign creates an SSHSIG over message within the given namespace (e.g. "git" or "file").
+// The message is hashed with sha512, like ssh-keygen does, unless another hash algorithm is given.
+func Sign(message string, privateKey *privatekey.PrivateKey, namespace string, hashAlgorithm ...string) Signature {
+	algorithm := Sha512
+	if len(hashAlgorithm) > 0 {
+		algorithm = hashAlgorithm[0]
+	}
+	if namespace == "" {
+		panic("SSH signatures require a namespace")
+	}
+
+	publicKey := privateKey.PublicKey()
+	signedData := signedData(message, namespace, algorithm)
+	sig := ecdsa.Sign(string(signedData), privateKey, publicKey.SshHash())
+
+	return Signature{
+		PublicKey:     publicKey,
+		Namespace:     namespace,
+		HashAlgorithm: algorithm,
+		Signature:     sig,
+	}
+}
+
+// Verify checks that sig is a valid SSHSIG over message, within namespace, made by publicKey
+func Verify(message string, sig Signature, publicKey *publickey.PublicKey, namespace string) bool {
+	if sig.Namespace != namespace {
+		return false
+	}
+	if _, ok := hashFuncs[sig.HashAlgorithm]; !ok {
+		return false
+	}
+	if !curve.IsOidEqual(sig.PublicKey.Curve.Oid, publicKey.Curve.Oid) ||
+		sig.PublicKey.Point.X.Cmp(publicKey.Point.X) != 0 ||
+		sig.PublicKey.Point.Y.Cmp(publicKey.Point.Y) != 0 {
+		return false
+	}
+	signedData := signedData(message, sig.Namespace, sig.HashAlgorithm)
+	return ecdsa.Verify(string(signedData), sig.Signature, publicKey, publicKey.SshHash())
+}
+
+func (obj Signature) ToBlob() []byte {
+	keyType := []byte(obj.PublicKey.SshKeyType())
+	blob := append([]byte(_magic), utils.SshUint32(_version)...)
+	blob = append(blob, utils.SshString(obj.PublicKey.ToSshBlob())...)
+	blob = append(blob, utils.SshString([]byte(obj.Namespace))...)
+	blob = append(blob, utils.SshString(nil)...)
+	blob = append(blob, utils.SshString([]byte(obj.HashAlgorithm))...)
+	sigBlob := append(utils.SshString(keyType), utils.SshString(obj.Signature.ToSsh())...)
+	return append(blob, utils.SshString(sigBlob)...)
+}
+
+// ToArmor returns the signature in the "BEGIN SSH SIGNATURE" armored format written by ssh-keygen
+func (obj Signature) ToArmor() string {
+	return utils.CreatePem(utils.Base64FromByteString(obj.ToBlob()), toArmorTemplate, 70)
+}
+
+func FromBlob(blob []byte) Signature {
+	if !bytes.HasPrefix(blob, []byte(_magic)) {
+		panic("SSH signature should start with the SSHSIG magic preamble")
+	}
+	reader := utils.SshReader{Data: blob[len(_magic):]}
+	version := reader.ReadUint32()
+	if version != _version {
+		panic(fmt.Sprintf("Unsupported SSH signature version %v", version))
+	}
+	publicKey := publickey.FromSshBlob(reader.ReadString())
+	namespace := string(reader.ReadString())
+	reader.ReadString() // reserved
+	hashAlgorithm := string(reader.ReadString())
+	sigReader := utils.SshReader{Data: reader.ReadString()}
+	if !reader.Empty() {
+		panic("trailing bytes in SSH signature")
+	}
+
+	sigKeyType := string(sigReader.ReadString())
+	if sigKeyType != publicKey.SshKeyType() {
+		panic(fmt.Sprintf("SSH signature type %v doesn't match its %v public key", sigKeyType, publicKey.SshKeyType()))
+	}
+	sig := signature.FromSsh(sigReader.ReadString())
+	if !sigReader.Empty() {
+		panic("trailing bytes in SSH signature blob")
+	}
+
+	return Signature{
+		PublicKey:     publicKey,
+		Namespace:     namespace,
+		HashAlgorithm: hashAlgorithm,
+		Signature:     sig,
+	}
+}
+
+func FromArmor(armor string) Signature {
+	content := utils.GetPemContent(armor, fromArmorTemplate)
+	return FromBlob(utils.ByteStringFromBase64(content))
+}
+
+// signedData builds the blob the ECDSA signature is computed over:
+// MAGIC_PREAMBLE || string(namespace) || string(reserved) || string(hash_algorithm) || string(H(message))
+func signedData(message string, namespace string, hashAlgorithm string) []byte {
+	hashFunc, ok := hashFuncs[hashAlgorithm]
+	if !ok {
+		panic(fmt.Sprintf("Unsupported SSH signature hash algorithm %v", hashAlgorithm))
+	}
+	h := hashFunc()
+	h.Write([]byte(message))
+
+	data := []byte(_magic)
+	data = append(data, utils.SshString([]byte(namespace))...)
+	data = append(data, utils.SshString(nil)...)
+	data = append(data, utils.SshString([]byte(hashAlgorithm))...)
+	return append(data, utils.SshString(h.Sum(nil))...)
+}
+
+const (
+	Sha256 = "sha256"
+	Sha512 = "sha512"
+)
+
+var hashFuncs = map[string]utils.HashFunc{
+	Sha256: utils.Sha256,
+	Sha512: utils.Sha512,
+}
+
+const _magic = "SSHSIG"
+const _version = 1
+
+const toArmorTemplate = `-----BEGIN SSH SIGNATURE-----
+{content}
+-----END SSH SIGNATURE-----
+`
+
+const fromArmorTemplate = `
+^\s*-----BEGIN SSH SIGNATURE-----
+{content}
+-----END SSH SIGNATURE-----\s*$
+`
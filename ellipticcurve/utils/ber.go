@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"math/big"
+)
+
+// Element is a tag-preserving view of one DER TLV, for structures whose optional
+// fields can only be told apart by their tags (e.g. CMS SignedData)
+type Element struct {
+	Tag     string
+	Content string
+	Raw     string
+}
+
+// ParseElements splits hexadecimal DER into its top-level TLVs without decoding them
+func ParseElements(hexadecimal string) []Element {
+	var elements []Element
+	for hexadecimal != "" {
+		if len(hexadecimal) < 4 {
+			panic("missing bytes in DER parse")
+		}
+		length, lengthBytes := ReadLengthBytes(hexadecimal[2:])
+		end := 2 + lengthBytes + length
+		if len(hexadecimal) < end {
+			panic("missing bytes in DER parse")
+		}
+		elements = append(elements, Element{
+			Tag:     hexadecimal[:2],
+			Content: hexadecimal[2+lengthBytes : end],
+			Raw:     hexadecimal[:end],
+		})
+		hexadecimal = hexadecimal[end:]
+	}
+	return elements
+}
+
+// ParseBer is the BER-tolerant mode of Parse, for formats such as CMS that are
+// often streamed with indefinite lengths and chunked strings
+func ParseBer(hexadecimal string) []interface{} {
+	return Parse(BerToDer(hexadecimal))
+}
+
+// BerToDer rewrites BER as DER by replacing indefinite lengths with definite ones
+// and merging constructed (chunked) strings into primitive ones. Other BER
+// liberties, such as unsorted SET OF, are preserved as they are.
+func BerToDer(hexadecimal string) string {
+	data := ByteStringFromHex(hexadecimal)
+	var der []byte
+	for len(data) > 0 {
+		var element []byte
+		element, data = berElementToDer(data)
+		der = append(der, element...)
+	}
+	return HexFromByteString(der)
+}
+
+func berElementToDer(data []byte) ([]byte, []byte) {
+	if len(data) < 2 {
+		panic("missing bytes in BER parse")
+	}
+	tag := data[0]
+	if tag&0x1f == 0x1f {
+		panic("high tag numbers are not supported in BER parse")
+	}
+	isConstructed := tag&0x20 != 0
+	data = data[1:]
+
+	var content []byte
+	if data[0] == 0x80 {
+		if !isConstructed {
+			panic("indefinite length encoding on a primitive BER element")
+		}
+		data = data[1:]
+		for {
+			if len(data) < 2 {
+				panic("missing end-of-contents in BER parse")
+			}
+			if data[0] == 0x00 && data[1] == 0x00 {
+				data = data[2:]
+				break
+			}
+			var child []byte
+			child, data = berElementToDer(data)
+			content = append(content, child...)
+		}
+	} else {
+		length, lengthBytes := readBerLength(data)
+		if len(data) < lengthBytes+length {
+			panic("missing bytes in BER parse")
+		}
+		content = data[lengthBytes : lengthBytes+length]
+		data = data[lengthBytes+length:]
+		if isConstructed {
+			content = ByteStringFromHex(BerToDer(HexFromByteString(content)))
+		}
+	}
+
+	if isConstructed && berStringTags[tag&^0x20] {
+		merged := ""
+		for _, chunk := range ParseElements(HexFromByteString(content)) {
+			merged += chunk.Content
+		}
+		tag &^= 0x20
+		content = ByteStringFromHex(merged)
+	}
+	element := EncodeTag(HexFromByteString([]byte{tag}), HexFromByteString(content))
+	return ByteStringFromHex(element), data
+}
+
+func readBerLength(data []byte) (int, int) {
+	if data[0] < 0x80 {
+		return int(data[0]), 1
+	}
+	lengthLength := int(data[0] & 0x7f)
+	if len(data) < 1+lengthLength {
+		panic("missing bytes in BER length")
+	}
+	length := new(big.Int).SetBytes(data[1 : 1+lengthLength])
+	if !length.IsInt64() || length.Int64() > int64(len(data)) {
+		panic("BER length exceeds the available data")
+	}
+	return int(length.Int64()), 1 + lengthLength
+}
+
+// berStringTags are the universal string types BER allows to be sent in constructed chunks
+var berStringTags = map[byte]bool{
+	0x04: true, // OCTET STRING
+	0x0c: true, // UTF8String
+	0x13: true, // PrintableString
+	0x16: true, // IA5String
+}
@@ -15,6 +15,7 @@ const (
 	Object                  = "object"
 	PrintableString         = "printableString"
 	UtcTime                 = "utcTime"
+	GeneralizedTime         = "generalizedTime"
 	Sequence                = "sequence"
 	Set                     = "set"
 	OidContainer            = "oidContainer"
@@ -29,6 +30,7 @@ var hexTagtoType = map[string]string{
 	"06": Object,
 	"13": PrintableString,
 	"17": UtcTime,
+	"18": GeneralizedTime,
 	"30": Sequence,
 	"31": Set,
 	"a0": OidContainer,
@@ -48,7 +50,12 @@ func EncodePrimitive(tagType string, value interface{}) string {
 	if tagType == Object {
 		value = OidToHex(value.([]int64))
 	}
-	return fmt.Sprintf("%s%s%s", typeToHexTag[tagType], GenerateLengthBytes(value.(string)), value)
+	return EncodeTag(typeToHexTag[tagType], value.(string))
+}
+
+// EncodeTag encodes hexadecimal content under an arbitrary hex tag, e.g. "a0" for [0] IMPLICIT constructed
+func EncodeTag(tag string, hexadecimal string) string {
+	return fmt.Sprintf("%s%s%s", tag, GenerateLengthBytes(hexadecimal), hexadecimal)
 }
 
 func Parse(hexadecimal string) []interface{} {
@@ -66,11 +73,7 @@ func Parse(hexadecimal string) []interface{} {
 
 	tagData := GetTagData(typeByte)
 	if tagData["isConstructed"].(bool) {
-		nextContent := Parse(hexadecimal)
-		if len(nextContent) == 0 {
-			return []interface{}{Parse(content)}
-		}
-		return append([]interface{}{Parse(content)}, nextContent[0])
+		return append([]interface{}{Parse(content)}, Parse(hexadecimal)...)
 	}
 
 	var contentArray []interface{}
@@ -81,6 +84,8 @@ func Parse(hexadecimal string) []interface{} {
 		contentArray = []interface{}{ParseOid(content)}
 	case UtcTime:
 		contentArray = []interface{}{ParseTime(content)}
+	case GeneralizedTime:
+		contentArray = []interface{}{ParseGeneralizedTime(content)}
 	case Integer:
 		contentArray = []interface{}{ParseInteger(content)}
 	case PrintableString:
@@ -102,10 +107,18 @@ func ParseOid(hexadecimal string) []int64 {
 func ParseTime(hexadecimal string) time.Time {
 	parsedHex := ParseString(hexadecimal)
 	layout := "060102150405"
+	if strings.HasSuffix(parsedHex, "Z") || strings.ContainsAny(parsedHex, "+-") {
+		layout = "060102150405Z0700"
+	}
 	parsedTime, _ := time.Parse(layout, parsedHex)
 	return parsedTime
 }
 
+func ParseGeneralizedTime(hexadecimal string) time.Time {
+	parsedTime, _ := time.Parse("20060102150405Z0700", ParseString(hexadecimal))
+	return parsedTime
+}
+
 func ParseString(hexadecimal string) string {
 	return string(ByteStringFromHex(hexadecimal)[:])
 }
@@ -24,6 +24,11 @@ func Sha256() hash.Hash {
 	return sha256.New()
 }
 
+// Sha384 returns a new SHA-384 hash
+func Sha384() hash.Hash {
+	return sha512.New384()
+}
+
 // Sha512 returns a new SHA-512 hash
 func Sha512() hash.Hash {
 	return sha512.New()
@@ -10,10 +10,15 @@ func GetPemContent(pem string, template string) string {
 	return regex.FindStringSubmatch(strings.Replace(pem, "\n", "", -1))[1]
 }
 
-func CreatePem(content string, template string) string {
+// CreatePem wraps content at 64 columns, or at lineLength when given, and fills the template with it
+func CreatePem(content string, template string, lineLength ...int) string {
+	width := 64
+	if len(lineLength) > 0 {
+		width = lineLength[0]
+	}
 	var lines []string
-	for start := 0; start < len(content); start += 64 {
-		end := start + 64
+	for start := 0; start < len(content); start += width {
+		end := start + width
 		if end > len(content) {
 			end = len(content)
 		}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Sm3 returns a new SM3 hash (GB/T 32905-2016), the hash SM2 signatures are made with
+func Sm3() hash.Hash {
+	h := &sm3{}
+	h.Reset()
+	return h
+}
+
+const (
+	sm3Size      = 32
+	sm3BlockSize = 64
+)
+
+var sm3Iv = [8]uint32{
+	0x7380166f, 0x4914b2b9, 0x172442d7, 0xda8a0600,
+	0xa96f30bc, 0x163138aa, 0xe38dee4d, 0xb0fb0e4e,
+}
+
+type sm3 struct {
+	state  [8]uint32
+	block  [sm3BlockSize]byte
+	filled int
+	length uint64
+}
+
+func (obj *sm3) Reset() {
+	obj.state = sm3Iv
+	obj.filled = 0
+	obj.length = 0
+}
+
+func (obj *sm3) Size() int {
+	return sm3Size
+}
+
+func (obj *sm3) BlockSize() int {
+	return sm3BlockSize
+}
+
+func (obj *sm3) Write(data []byte) (int, error) {
+	written := len(data)
+	obj.length += uint64(written)
+	if obj.filled > 0 {
+		n := copy(obj.block[obj.filled:], data)
+		obj.filled += n
+		data = data[n:]
+		if obj.filled < sm3BlockSize {
+			return written, nil
+		}
+		obj.compress(obj.block[:])
+		obj.filled = 0
+	}
+	for len(data) >= sm3BlockSize {
+		obj.compress(data[:sm3BlockSize])
+		data = data[sm3BlockSize:]
+	}
+	obj.filled = copy(obj.block[:], data)
+	return written, nil
+}
+
+// Sum appends the digest to in without changing the running hash
+func (obj *sm3) Sum(in []byte) []byte {
+	final := *obj
+	bitLength := final.length * 8
+	padding := make([]byte, 1, sm3BlockSize+8)
+	padding[0] = 0x80
+	for (final.length+uint64(len(padding)))%sm3BlockSize != sm3BlockSize-8 {
+		padding = append(padding, 0)
+	}
+	var encoded [8]byte
+	binary.BigEndian.PutUint64(encoded[:], bitLength)
+	final.Write(append(padding, encoded[:]...))
+
+	for _, word := range final.state {
+		binary.BigEndian.PutUint32(encoded[:4], word)
+		in = append(in, encoded[:4]...)
+	}
+	return in
+}
+
+// compress runs the SM3 compression function over one 64-byte block
+func (obj *sm3) compress(block []byte) {
+	var w [68]uint32
+	for i := 0; i < 16; i++ {
+		w[i] = binary.BigEndian.Uint32(block[4*i:])
+	}
+	for i := 16; i < 68; i++ {
+		x := w[i-16] ^ w[i-9] ^ bits.RotateLeft32(w[i-3], 15)
+		w[i] = x ^ bits.RotateLeft32(x, 15) ^ bits.RotateLeft32(x, 23) ^ bits.RotateLeft32(w[i-13], 7) ^ w[i-6]
+	}
+
+	a, b, c, d := obj.state[0], obj.state[1], obj.state[2], obj.state[3]
+	e, f, g, h := obj.state[4], obj.state[5], obj.state[6], obj.state[7]
+	for j := 0; j < 64; j++ {
+		t := uint32(0x79cc4519)
+		if j >= 16 {
+			t = 0x7a879d8a
+		}
+		ss1 := bits.RotateLeft32(bits.RotateLeft32(a, 12)+e+bits.RotateLeft32(t, j%32), 7)
+		ss2 := ss1 ^ bits.RotateLeft32(a, 12)
+		var ff, gg uint32
+		if j < 16 {
+			ff = a ^ b ^ c
+			gg = e ^ f ^ g
+		} else {
+			ff = (a & b) | (a & c) | (b & c)
+			gg = (e & f) | (^e & g)
+		}
+		tt1 := ff + d + ss2 + (w[j] ^ w[j+4])
+		tt2 := gg + h + ss1 + w[j]
+		d = c
+		c = bits.RotateLeft32(b, 9)
+		b = a
+		a = tt1
+		h = g
+		g = bits.RotateLeft32(f, 19)
+		f = e
+		e = tt2 ^ bits.RotateLeft32(tt2, 9) ^ bits.RotateLeft32(tt2, 17)
+	}
+
+	obj.state[0] ^= a
+	obj.state[1] ^= b
+	obj.state[2] ^= c
+	obj.state[3] ^= d
+	obj.state[4] ^= e
+	obj.state[5] ^= f
+	obj.state[6] ^= g
+	obj.state[7] ^= h
+}
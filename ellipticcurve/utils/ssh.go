@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"encoding/binary"
+	"math/big"
+)
+
+// SshUint32 encodes n as a big-endian uint32 (RFC 4251 §5)
+func SshUint32(n uint32) []byte {
+	data := make([]byte, 4)
+	binary.BigEndian.PutUint32(data, n)
+	return data
+}
+
+// SshString encodes data as an SSH string: uint32 length followed by the bytes
+func SshString(data []byte) []byte {
+	return append(SshUint32(uint32(len(data))), data...)
+}
+
+// SshMpint encodes a non-negative integer as an SSH mpint: minimal big-endian
+// two's complement, with a leading zero byte when the high bit would be set
+func SshMpint(number *big.Int) []byte {
+	data := number.Bytes()
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		data = append([]byte{0x00}, data...)
+	}
+	return SshString(data)
+}
+
+// SshReader consumes SSH wire encoded values from a byte slice. Every read
+// panics when the remaining data is too short for the requested value.
+type SshReader struct {
+	Data []byte
+}
+
+func (obj *SshReader) ReadUint8() byte {
+	if len(obj.Data) < 1 {
+		panic("missing bytes in SSH byte")
+	}
+	value := obj.Data[0]
+	obj.Data = obj.Data[1:]
+	return value
+}
+
+func (obj *SshReader) ReadUint32() uint32 {
+	if len(obj.Data) < 4 {
+		panic("missing bytes in SSH uint32")
+	}
+	value := binary.BigEndian.Uint32(obj.Data)
+	obj.Data = obj.Data[4:]
+	return value
+}
+
+func (obj *SshReader) ReadString() []byte {
+	length := obj.ReadUint32()
+	if uint32(len(obj.Data)) < length {
+		panic("missing bytes in SSH string")
+	}
+	value := obj.Data[:length]
+	obj.Data = obj.Data[length:]
+	return value
+}
+
+func (obj *SshReader) ReadMpint() *big.Int {
+	data := obj.ReadString()
+	if len(data) > 0 && data[0]&0x80 != 0 {
+		panic("negative SSH mpint")
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// Empty reports whether all data has been consumed
+func (obj *SshReader) Empty() bool {
+	return len(obj.Data) == 0
+}
@@ -0,0 +1,124 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// backendCurves are the curves with a fixed-limb backend
+var backendCurves = []curve.CurveFp{curve.Secp256k1, curve.Prime256v1}
+
+// withoutBackend returns c on the big.Int arithmetic, to compare its backend with
+func withoutBackend(c curve.CurveFp) curve.CurveFp {
+	c.Backend = nil
+	return c
+}
+
+func TestBackendsMatchGeneric(t *testing.T) {
+	for _, c := range backendCurves {
+		generic := withoutBackend(c)
+		if c.Backend == nil {
+			t.Fatalf("TestBackendsMatchGeneric: %v has no backend", c.Name)
+		}
+
+		scalars := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			big.NewInt(2),
+			big.NewInt(15),
+			big.NewInt(16),
+			big.NewInt(-5),
+			new(big.Int).Sub(c.N, big.NewInt(1)),
+			c.N,
+			new(big.Int).Add(c.N, big.NewInt(3)),
+			new(big.Int).Lsh(big.NewInt(1), 255),
+		}
+		// N - 16^i and 16^i - 1 put the sums of the windows right next to N and to a window
+		for _, i := range []uint{1, 32, 63} {
+			window := new(big.Int).Lsh(big.NewInt(1), 4*i)
+			scalars = append(scalars, new(big.Int).Sub(c.N, window), new(big.Int).Sub(window, big.NewInt(1)))
+		}
+		for i := 0; i < 10; i++ {
+			scalars = append(scalars, utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1))))
+		}
+		q := generic.MultiplyGenerator(big.NewInt(123456789))
+
+		for _, k := range scalars {
+			if !samePoint(c.MultiplyGenerator(k), generic.MultiplyGenerator(k)) {
+				t.Fatalf("TestBackendsMatchGeneric: %v*G differs on %v", k, c.Name)
+			}
+			if !samePoint(c.Multiply(q, k), generic.Multiply(q, k)) {
+				t.Fatalf("TestBackendsMatchGeneric: %v*Q differs on %v", k, c.Name)
+			}
+			for _, k2 := range scalars[:8] {
+				if !samePoint(c.MultiplyAndAdd(c.G, k, q, k2), generic.MultiplyAndAdd(c.G, k, q, k2)) {
+					t.Fatalf("TestBackendsMatchGeneric: %v*G + %v*Q differs on %v", k, k2, c.Name)
+				}
+			}
+		}
+
+		// P + P and P - P inside the multi-scalar multiplication
+		if !samePoint(c.MultiplyAndAdd(q, big.NewInt(3), q, big.NewInt(3)), generic.MultiplyGenerator(big.NewInt(6*123456789))) {
+			t.Fatalf("TestBackendsMatchGeneric: 3*Q + 3*Q isn't 6*Q on %v", c.Name)
+		}
+		if !c.MultiplyAndAdd(q, big.NewInt(3), q, new(big.Int).Sub(c.N, big.NewInt(3))).IsAtInfinity() {
+			t.Fatalf("TestBackendsMatchGeneric: 3*Q - 3*Q isn't the point at infinity on %v", c.Name)
+		}
+	}
+}
+
+func TestBackendCompleteAddition(t *testing.T) {
+	for _, c := range backendCurves {
+		generic := withoutBackend(c)
+		// the window of 2^256 adds 2^256*G to the sum of the windows below it, which is
+		// (2^256 mod N)*G, the same point, or -(2^256 mod N)*G, its opposite
+		window := new(big.Int).Lsh(big.NewInt(1), 256)
+		low := new(big.Int).Mod(window, c.N)
+		same := new(big.Int).Add(window, low)
+		opposite := new(big.Int).Add(window, new(big.Int).Sub(c.N, low))
+		above := new(big.Int).Lsh(big.NewInt(1), 260)
+
+		if !samePoint(c.MultiplyGenerator(same), generic.MultiplyGenerator(new(big.Int).Lsh(low, 1))) {
+			t.Fatalf("TestBackendCompleteAddition: P + P differs on %v", c.Name)
+		}
+		if !samePoint(c.MultiplyGenerator(same, c.RandomFieldElement()), generic.MultiplyGenerator(new(big.Int).Lsh(low, 1))) {
+			t.Fatalf("TestBackendCompleteAddition: P + P with a random Z differs on %v", c.Name)
+		}
+		if !c.MultiplyGenerator(opposite).IsAtInfinity() {
+			t.Fatalf("TestBackendCompleteAddition: P - P isn't the point at infinity on %v", c.Name)
+		}
+		// the sum goes on from the point at infinity
+		if !samePoint(c.MultiplyGenerator(opposite.Add(opposite, above)), generic.MultiplyGenerator(above)) {
+			t.Fatalf("TestBackendCompleteAddition: P - P + R differs on %v", c.Name)
+		}
+	}
+}
+
+func TestBackendSignatures(t *testing.T) {
+	for _, c := range backendCurves {
+		generic := withoutBackend(c)
+		for i := 0; i < 5; i++ {
+			privateKey := privatekey.New(c)
+			genericKey := privatekey.New(generic, privateKey.Secret)
+			publicKey, genericPublicKey := privateKey.PublicKey(), genericKey.PublicKey()
+			if publicKey.ToString(true) != genericPublicKey.ToString(true) {
+				t.Fatalf("TestBackendSignatures: the public keys differ on %v", c.Name)
+			}
+
+			// signatures are hedged with fresh entropy, so each path verifies the other's
+			sig := ecdsa.Sign("message", &privateKey)
+			genericSig := ecdsa.Sign("message", &genericKey)
+			if !ecdsa.Verify("message", sig, &genericPublicKey) || !ecdsa.Verify("message", genericSig, &publicKey) {
+				t.Fatalf("TestBackendSignatures: a signature didn't verify on %v", c.Name)
+			}
+			if ecdsa.Verify("other message", sig, &publicKey) {
+				t.Fatalf("TestBackendSignatures: a signature of another message verified on %v", c.Name)
+			}
+		}
+	}
+}
@@ -36,3 +36,64 @@ func BenchmarkVerify(b *testing.B) {
 		ecdsa.Verify(message, sig, &pub)
 	}
 }
+
+func BenchmarkSignGeneric(b *testing.B) {
+	pk := privatekey.New(withoutBackend(curve.Secp256k1))
+	message := "This is a benchmark test message"
+
+	// Warmup
+	ecdsa.Sign(message, &pk)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ecdsa.Sign(message, &pk)
+	}
+}
+
+func BenchmarkVerifyGeneric(b *testing.B) {
+	pk := privatekey.New(withoutBackend(curve.Secp256k1))
+	pub := pk.PublicKey()
+	message := "This is a benchmark test message"
+
+	sig := ecdsa.Sign(message, &pk)
+
+	// Warmup
+	ecdsa.Verify(message, sig, &pub)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ecdsa.Verify(message, sig, &pub)
+	}
+}
+
+func BenchmarkVerifyPrepared(b *testing.B) {
+	pk := privatekey.New(curve.Secp256k1)
+	pub := pk.PublicKey().Precompute()
+	message := "This is a benchmark test message"
+
+	sig := ecdsa.Sign(message, &pk)
+
+	// Warmup
+	ecdsa.VerifyPrepared(message, sig, &pub)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ecdsa.VerifyPrepared(message, sig, &pub)
+	}
+}
+
+func BenchmarkVerifyPreparedGeneric(b *testing.B) {
+	pk := privatekey.New(withoutBackend(curve.Secp256k1))
+	pub := pk.PublicKey().Precompute()
+	message := "This is a benchmark test message"
+
+	sig := ecdsa.Sign(message, &pk)
+
+	// Warmup
+	ecdsa.VerifyPrepared(message, sig, &pub)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ecdsa.VerifyPrepared(message, sig, &pub)
+	}
+}
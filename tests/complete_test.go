@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// The constant-time multiplications run on the complete formulas, and MultiplyAndAdd
+// on the Jacobian ones, so they check each other, A = 0, A = -3 and any other A alike
+func TestCompleteFormulasMatchJacobian(t *testing.T) {
+	curve.Each(func(c curve.CurveFp) bool {
+		if c.Binary != nil {
+			return true
+		}
+		c = withoutBackend(c)
+		q := c.Multiply(c.G, big.NewInt(7))
+		scalars := []*big.Int{
+			big.NewInt(1),
+			big.NewInt(2),
+			new(big.Int).Sub(c.N, big.NewInt(1)),
+			utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1))),
+		}
+		for _, k := range scalars {
+			expected := c.MultiplyAndAdd(q, k, c.G, big.NewInt(0))
+			if !samePoint(c.Multiply(q, k), expected) {
+				t.Fatalf("TestCompleteFormulasMatchJacobian: %v*Q differs on %v", k, c.Name)
+			}
+			expected = c.MultiplyAndAdd(c.G, k, c.G, big.NewInt(0))
+			if !samePoint(c.MultiplyGenerator(k), expected) {
+				t.Fatalf("TestCompleteFormulasMatchJacobian: %v*G differs on %v", k, c.Name)
+			}
+		}
+		if !c.EqualPoints(c.Multiply(q, new(big.Int).Sub(c.N, big.NewInt(1))), c.Neg(q)) {
+			t.Fatalf("TestCompleteFormulasMatchJacobian: (N - 1)*Q isn't -Q on %v", c.Name)
+		}
+		return true
+	})
+}
+
+func TestCompleteFormulasOutsideSubgroup(t *testing.T) {
+	c := curve.Gost256A
+	outside := pointOutsideSubgroup(c)
+	for _, k := range []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), c.H} {
+		expected := c.MultiplyAndAdd(outside, k, c.G, big.NewInt(0))
+		if !samePoint(c.Multiply(outside, k), expected) {
+			t.Fatalf("TestCompleteFormulasOutsideSubgroup: %v*P differs on %v", k, c.Name)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+func TestRandomizedZ(t *testing.T) {
+	curves := []curve.CurveFp{withoutBackend(curve.Secp256k1), withoutBackend(curve.Prime256v1)}
+	curve.Each(func(c curve.CurveFp) bool {
+		curves = append(curves, c)
+		return true
+	})
+	for _, c := range curves {
+		k := utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1)))
+		expected := c.MultiplyGenerator(k)
+		for i := 0; i < 3; i++ {
+			if !samePoint(c.MultiplyGenerator(k, c.RandomFieldElement()), expected) {
+				t.Fatalf("TestRandomizedZ: a random Z changes k*G on %v", c.Name)
+			}
+		}
+		if !c.MultiplyGenerator(big.NewInt(0), c.RandomFieldElement()).IsAtInfinity() {
+			t.Fatalf("TestRandomizedZ: 0*G isn't the point at infinity on %v", c.Name)
+		}
+		zero := big.NewInt(0)
+		if c.Binary == nil {
+			zero = c.P
+		}
+		assertPanics(t, "MultiplyGenerator with a zero Z on "+c.Name, func() {
+			c.MultiplyGenerator(k, zero)
+		})
+	}
+}
+
+func TestBlindedScalar(t *testing.T) {
+	curves := []curve.CurveFp{withoutBackend(curve.Secp256k1), withoutBackend(curve.Prime256v1)}
+	curve.Each(func(c curve.CurveFp) bool {
+		curves = append(curves, c)
+		return true
+	})
+	largest := new(big.Int).Lsh(big.NewInt(1), ecmath.ScalarBlindingBits)
+	largest.Sub(largest, big.NewInt(1))
+	for _, c := range curves {
+		random := utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1)))
+		for _, k := range []*big.Int{big.NewInt(1), random, new(big.Int).Sub(c.N, big.NewInt(1))} {
+			expected := c.MultiplyGenerator(k)
+			for _, m := range []*big.Int{big.NewInt(1), utils.Between(big.NewInt(1), largest), largest} {
+				blinded := new(big.Int).Add(k, new(big.Int).Mul(m, c.N))
+				if !samePoint(c.MultiplyGenerator(blinded), expected) {
+					t.Fatalf("TestBlindedScalar: (k + m*N)*G isn't k*G on %v", c.Name)
+				}
+				if !samePoint(c.MultiplyGenerator(blinded, c.RandomFieldElement()), expected) {
+					t.Fatalf("TestBlindedScalar: (k + m*N)*G with a random Z isn't k*G on %v", c.Name)
+				}
+			}
+		}
+		if !c.MultiplyGenerator(new(big.Int).Mul(largest, c.N)).IsAtInfinity() {
+			t.Fatalf("TestBlindedScalar: m*N*G isn't the point at infinity on %v", c.Name)
+		}
+	}
+}
+
+// checkCountermeasureSignature verifies sig and checks that it keeps the low S and, on
+// prime curves, the recovery id of the point R = (h/s)*G + (r/s)*Q it verifies against
+func checkCountermeasureSignature(t *testing.T, name string, message string, sig signature.Signature, privateKey privatekey.PrivateKey) {
+	publicKey := privateKey.PublicKey()
+	if !ecdsa.Verify(message, sig, &publicKey) {
+		t.Fatalf("%v: the signature doesn't verify on %v", name, privateKey.Curve.Name)
+	}
+	c := privateKey.Curve
+	if sig.S.Cmp(new(big.Int).Rsh(c.N, 1)) > 0 {
+		t.Fatalf("%v: S isn't low on %v", name, c.Name)
+	}
+	if c.Binary != nil {
+		return
+	}
+	h := utils.Sha256()
+	h.Write([]byte(message))
+	numberMessage := utils.NumberFromByteString(h.Sum(nil), c.NBitLength)
+	sInv := ecmath.Inv(&sig.S, c.N)
+	u1 := new(big.Int).Mul(numberMessage, sInv)
+	u2 := new(big.Int).Mul(&sig.R, sInv)
+	R := c.MultiplyAndAdd(c.G, u1.Mod(u1, c.N), publicKey.Point, u2.Mod(u2, c.N))
+	if int(R.Y.Bit(0)) != sig.RecoveryId&1 || (R.X.Cmp(c.N) > 0) != (sig.RecoveryId >= 2) {
+		t.Fatalf("%v: wrong recovery id on %v", name, c.Name)
+	}
+}
+
+func TestSignWithCountermeasures(t *testing.T) {
+	message := "This is a text message"
+	all := privatekey.Countermeasures{ScalarBlinding: true, RandomizedZ: true, BlindedInversion: true, BlindedSecret: true}
+	configurations := []privatekey.Countermeasures{
+		{ScalarBlinding: true},
+		{RandomizedZ: true},
+		{BlindedInversion: true},
+		{BlindedSecret: true},
+		all,
+	}
+	curves := []curve.CurveFp{
+		curve.Secp256k1,
+		withoutBackend(curve.Secp256k1),
+		curve.Prime256v1,
+		curve.BrainpoolP256r1,
+		curve.Secp384r1,
+		curve.Sect233k1,
+	}
+	for _, c := range curves {
+		for _, countermeasures := range configurations {
+			countermeasures := countermeasures
+			privateKey := privatekey.New(c)
+			privateKey.Countermeasures = &countermeasures
+			for i := 0; i < 3; i++ {
+				checkCountermeasureSignature(t, "TestSignWithCountermeasures", message, ecdsa.Sign(message, &privateKey), privateKey)
+			}
+		}
+	}
+}
+
+func TestDefaultCountermeasures(t *testing.T) {
+	defer privatekey.SetDefaultCountermeasures(privatekey.GetDefaultCountermeasures())
+
+	defaults := privatekey.Countermeasures{ScalarBlinding: true, RandomizedZ: true}
+	privatekey.SetDefaultCountermeasures(defaults)
+	privateKey := privatekey.New(curve.Prime256v1)
+	if privateKey.SigningCountermeasures() != defaults {
+		t.Fatal("TestDefaultCountermeasures: the key doesn't fall back to the defaults")
+	}
+	message := "This is a text message"
+	checkCountermeasureSignature(t, "TestDefaultCountermeasures", message, ecdsa.Sign(message, &privateKey), privateKey)
+
+	// the key's own countermeasures take precedence, even when all are off
+	privateKey.Countermeasures = &privatekey.Countermeasures{}
+	if privateKey.SigningCountermeasures() != (privatekey.Countermeasures{}) {
+		t.Fatal("TestDefaultCountermeasures: the key's countermeasures don't override the defaults")
+	}
+}
+
+func TestDefaultCountermeasuresWhileSigning(t *testing.T) {
+	defer privatekey.SetDefaultCountermeasures(privatekey.GetDefaultCountermeasures())
+
+	message := "This is a text message"
+	privateKey := privatekey.New(curve.Secp256k1)
+	publicKey := privateKey.PublicKey()
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 20; i++ {
+			privatekey.SetDefaultCountermeasures(privatekey.Countermeasures{RandomizedZ: i%2 == 0, BlindedSecret: i%3 == 0})
+		}
+		done <- true
+	}()
+	for i := 0; i < 20; i++ {
+		if !ecdsa.Verify(message, ecdsa.Sign(message, &privateKey), &publicKey) {
+			t.Fatal("TestDefaultCountermeasuresWhileSigning: the signature doesn't verify")
+		}
+	}
+	<-done
+}
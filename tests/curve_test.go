@@ -1,6 +1,8 @@
 package tests
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
@@ -76,14 +78,14 @@ func TestAddNewCurve(t *testing.T) {
 
 func TestUnsupportedCurve(t *testing.T) {
 	newCurve := curve.New(
-		"brainpoolP256t1",
-		"0xa9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5374",
-		"0x662c61c430d84ea4fe66a7733d0b76b7bf93ebc4af2f49256ae58101fee92b04",
-		"0xa9fb57dba1eea9bc3e660a909d838d726e3bf623d52620282013481d1f6e5377",
-		"0xa9fb57dba1eea9bc3e660a909d838d718c397aa3b561a6f7901e0e82974856a7",
-		"0xa3e8eb3cc1cfe7b7732213b23a656149afa142c47aafbc2b79a191562e1305f4",
-		"0x2d996c823439c56d7f7b22e14644417e69bcb6de39d027001dabe8f35b25c9be",
-		[]int64{1, 3, 36, 3, 3, 2, 8, 1, 1, 8},
+		"secp224r1",
+		"0xfffffffffffffffffffffffffffffffefffffffffffffffffffffffe",
+		"0xb4050a850c04b3abf54132565044b0b7d7bfd8ba270b39432355ffb4",
+		"0xffffffffffffffffffffffffffffffff000000000000000000000001",
+		"0xffffffffffffffffffffffffffff16a2e0b8f03e13dd29455c5c2a3d",
+		"0xb70e0cbd6bb4bf7f321390b94a03c1d356c21122343280d6115c1d21",
+		"0xbd376388b5f723fb4c22dfe6cd4375a05a07476444d5819985007e34",
+		[]int64{1, 3, 132, 0, 33},
 		"",
 	)
 
@@ -98,3 +100,127 @@ func TestUnsupportedCurve(t *testing.T) {
 		publickey.FromPem(publicKeyPem)
 	})
 }
+
+func TestCurveLookup(t *testing.T) {
+	for _, name := range []string{"secp256r1", "P-256", "prime256v1", "nistp256"} {
+		c, err := curve.ByName(name)
+		if err != nil || c.Name != curve.Prime256v1.Name {
+			t.Fatalf("TestCurveLookup: %v found %v, %v", name, c.Name, err)
+		}
+	}
+	if c, err := curve.ByNistName("P-384"); err != nil || c.Name != curve.Secp384r1.Name {
+		t.Fatalf("TestCurveLookup: P-384 found %v, %v", c.Name, err)
+	}
+	if _, err := curve.ByNistName("secp384r1"); err == nil {
+		t.Fatal("TestCurveLookup: ByNistName accepted a SEC name")
+	}
+	if c, err := curve.ByOid([]int64{1, 3, 132, 0, 10}); err != nil || c.Name != curve.Secp256k1.Name {
+		t.Fatalf("TestCurveLookup: secp256k1 OID found %v, %v", c.Name, err)
+	}
+	if _, err := curve.ByName("secp224r1"); err == nil {
+		t.Fatal("TestCurveLookup: found an unregistered curve")
+	}
+	if _, err := curve.ByOid([]int64{1, 3, 132, 0, 33}); err == nil {
+		t.Fatal("TestCurveLookup: found an unregistered OID")
+	}
+
+	count := 0
+	curve.Each(func(c curve.CurveFp) bool {
+		count++
+		return c.Name != curve.Prime256v1.Name
+	})
+	if count != 2 {
+		t.Fatalf("TestCurveLookup: Each didn't stop after prime256v1, visited %v curves", count)
+	}
+}
+
+func TestCurveRegistration(t *testing.T) {
+	// registering a built-in curve again is harmless
+	if err := curve.Register(curve.Secp256k1, "bitcoin"); err != nil {
+		t.Fatalf("TestCurveRegistration: %v", err)
+	}
+	if c, err := curve.ByName("bitcoin"); err != nil || c.Name != curve.Secp256k1.Name {
+		t.Fatalf("TestCurveRegistration: alias found %v, %v", c.Name, err)
+	}
+
+	fakeSecp256k1 := curve.New(
+		"secp256k1",
+		"0x0000000000000000000000000000000000000000000000000000000000000000",
+		"0x0000000000000000000000000000000000000000000000000000000000000005",
+		"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+		"0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+		"0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		"0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+		[]int64{1, 3, 132, 0, 10},
+		"",
+	)
+	if err := curve.Register(fakeSecp256k1); err == nil {
+		t.Fatal("TestCurveRegistration: a different curve replaced secp256k1")
+	}
+	secp224r1 := curve.New(
+		"secp224r1",
+		"0xfffffffffffffffffffffffffffffffefffffffffffffffffffffffe",
+		"0xb4050a850c04b3abf54132565044b0b7d7bfd8ba270b39432355ffb4",
+		"0xffffffffffffffffffffffffffffffff000000000000000000000001",
+		"0xffffffffffffffffffffffffffff16a2e0b8f03e13dd29455c5c2a3d",
+		"0xb70e0cbd6bb4bf7f321390b94a03c1d356c21122343280d6115c1d21",
+		"0xbd376388b5f723fb4c22dfe6cd4375a05a07476444d5819985007e34",
+		[]int64{1, 3, 132, 0, 33},
+		"P-224",
+	)
+	if err := curve.Register(secp224r1, "P-256"); err == nil {
+		t.Fatal("TestCurveRegistration: an alias of prime256v1 was taken over")
+	}
+	if _, err := curve.ByOid(secp224r1.Oid); err == nil {
+		t.Fatal("TestCurveRegistration: a rejected curve was registered anyway")
+	}
+	assertPanics(t, "curve.Add with a conflicting curve", func() {
+		curve.Add(fakeSecp256k1)
+	})
+}
+
+// curves without an OID don't share one under the empty OID
+func TestCurveRegistrationWithoutOid(t *testing.T) {
+	for _, name := range []string{"customA", "customB"} {
+		custom := curve.New(
+			name,
+			"0xfffffffffffffffffffffffffffffffefffffffffffffffffffffffe",
+			"0xb4050a850c04b3abf54132565044b0b7d7bfd8ba270b39432355ffb4",
+			"0xffffffffffffffffffffffffffffffff000000000000000000000001",
+			"0xffffffffffffffffffffffffffff16a2e0b8f03e13dd29455c5c2a3d",
+			"0xb70e0cbd6bb4bf7f321390b94a03c1d356c21122343280d6115c1d21",
+			"0xbd376388b5f723fb4c22dfe6cd4375a05a07476444d5819985007e34",
+			[]int64{},
+			"",
+		)
+		curve.Add(custom)
+		if err := curve.Register(custom, name+"-alias"); err != nil {
+			t.Fatalf("TestCurveRegistrationWithoutOid: %v", err)
+		}
+		if c, err := curve.ByName(name + "-alias"); err != nil || c.Name != name {
+			t.Fatalf("TestCurveRegistrationWithoutOid: alias found %v, %v", c.Name, err)
+		}
+	}
+	if _, err := curve.ByOid([]int64{}); err == nil {
+		t.Fatal("TestCurveRegistrationWithoutOid: a curve was registered under the empty OID")
+	}
+}
+
+func TestConcurrentCurveRegistration(t *testing.T) {
+	var wait sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wait.Add(1)
+		go func(i int) {
+			defer wait.Done()
+			alias := fmt.Sprintf("concurrent-%v", i)
+			if err := curve.Register(curve.Secp256k1, alias); err != nil {
+				t.Errorf("TestConcurrentCurveRegistration: %v", err)
+			}
+			if _, err := curve.ByName(alias); err != nil {
+				t.Errorf("TestConcurrentCurveRegistration: %v", err)
+			}
+			curve.Each(func(c curve.CurveFp) bool { return true })
+		}(i)
+	}
+	wait.Wait()
+}
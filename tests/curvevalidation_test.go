@@ -0,0 +1,149 @@
+package tests
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+)
+
+var validationSecp256k1 = []string{
+	"0x0000000000000000000000000000000000000000000000000000000000000000",
+	"0x0000000000000000000000000000000000000000000000000000000000000007",
+	"0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f",
+	"0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+	"0x79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+	"0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8",
+}
+
+func newValidationCurve(parameters []string, glv ...*ecmath.GLVParams) (curve.CurveFp, error) {
+	return curve.NewValidated(
+		"test",
+		parameters[0],
+		parameters[1],
+		parameters[2],
+		parameters[3],
+		parameters[4],
+		parameters[5],
+		[]int64{1, 2, 3},
+		"",
+		glv...,
+	)
+}
+
+// withParameter returns a copy of the secp256k1 parameters with one of them replaced
+func withParameter(index int, value string) []string {
+	parameters := append([]string{}, validationSecp256k1...)
+	parameters[index] = value
+	return parameters
+}
+
+func TestRegisteredCurvesValidate(t *testing.T) {
+	curve.Each(func(c curve.CurveFp) bool {
+		if err := c.Validate(); err != nil {
+			t.Errorf("TestRegisteredCurvesValidate: %v", err)
+		}
+		return true
+	})
+}
+
+func TestNewValidated(t *testing.T) {
+	c, err := newValidationCurve(validationSecp256k1, curve.Secp256k1.GLVParams)
+	if err != nil {
+		t.Fatalf("TestNewValidated: secp256k1 failed with %v", err)
+	}
+	if c.N.Cmp(curve.Secp256k1.N) != 0 || c.GLVParams == nil {
+		t.Fatal("TestNewValidated: wrong secp256k1 parameters")
+	}
+}
+
+func TestCurveValidationFailures(t *testing.T) {
+	wrongBeta := *curve.Secp256k1.GLVParams
+	wrongBeta.Beta = new(big.Int).Exp(wrongBeta.Beta, big.NewInt(2), curve.Secp256k1.P)
+	wrongBasis := *curve.Secp256k1.GLVParams
+	wrongBasis.A1 = new(big.Int).Add(wrongBasis.A1, big.NewInt(1))
+
+	cases := []struct {
+		name       string
+		parameters []string
+		glv        *ecmath.GLVParams
+		error      string
+	}{
+		{"a typo in Gy", withParameter(5, "0x483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4g8"), nil, "isn't a valid number"},
+		{"an empty B", withParameter(1, ""), nil, "isn't a valid number"},
+		{"a composite P", withParameter(2, "0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2d"), nil, "P isn't an odd prime"},
+		{"a composite N", withParameter(3, "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364143"), nil, "N isn't prime"},
+		{"a singular curve", withParameter(1, "0x00"), nil, "singular"},
+		{"G off the curve", withParameter(1, "0x05"), nil, "G isn't on the curve"},
+		{"a wrong N", withParameter(3, "0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364111"), nil, "N*G isn't the point at infinity"},
+		{"a wrong GLV Beta", validationSecp256k1, &wrongBeta, "lambda*G"},
+		{"a wrong GLV basis", validationSecp256k1, &wrongBasis, "different lambdas"},
+		{
+			// y^2 = x^3 + x over P = 3 mod 4 is supersingular, with P + 1 points
+			"an embedding degree of 2",
+			[]string{
+				"0x01",
+				"0x00",
+				"0xb78455060d6ee014161748fe5766b643",
+				"0x2de11541835bb8050585d23f95d9ad91",
+				"0x70144a0710fe1cfbad84c8378985df73",
+				"0x29134d40618ca3bf93c15e50736555c4",
+			},
+			nil,
+			"embedding degree 2",
+		},
+		{
+			// Smart's anomalous curve, with exactly P points
+			"an anomalous curve",
+			[]string{
+				"0x4a9155f77369ea270678586d3a7acf3d3983d99a",
+				"0x239f1c05b30eb93b682fb4d73c8c32151836d536",
+				"0x800000000000000022bfe7b0154d1a5cddd6196b",
+				"0x800000000000000022bfe7b0154d1a5cddd6196b",
+				"0x03",
+				"0x06b51b9763ffcea49465172db2ca75266c8fd1b3",
+			},
+			nil,
+			"anomalous",
+		},
+	}
+	for _, testCase := range cases {
+		var glv []*ecmath.GLVParams
+		if testCase.glv != nil {
+			glv = append(glv, testCase.glv)
+		}
+		_, err := newValidationCurve(testCase.parameters, glv...)
+		if err == nil || !strings.Contains(err.Error(), testCase.error) {
+			t.Fatalf("TestCurveValidationFailures: %v returned %v instead of %q", testCase.name, err, testCase.error)
+		}
+	}
+
+	assertPanics(t, "curve.New with an invalid number", func() {
+		curve.New("test", "0x00", "0x07", "0xzz", "0x01", "0x01", "0x01", []int64{1, 2, 3}, "")
+	})
+}
+
+func TestBinaryCurveValidation(t *testing.T) {
+	// x^233 + x^74 + 1 is irreducible, but x^233 + x^74 + x + 1 has the root 1
+	reducible := curve.NewBinary(
+		"test",
+		"0x00",
+		"0x01",
+		"0x020000000000000000000000000000000000000004000000000000000003",
+		"0x8000000000000000000000000000069d5bb915bcd46efb1ad5f173abdf",
+		"0x017232ba853a7e731af129f22ff4149563a419c26bf50a4c9d6eefad6126",
+		"0x01db537dece819b7f70f555a67c427a8cd9bf18aeb9b56e0c11056fae6a3",
+		[]int64{1, 2, 3},
+		"",
+	)
+	if err := reducible.Validate(); err == nil || !strings.Contains(err.Error(), "irreducible") {
+		t.Fatalf("TestBinaryCurveValidation: a reducible polynomial returned %v", err)
+	}
+	singular := curve.Sect233k1
+	singular.B = big.NewInt(0)
+	if err := singular.Validate(); err == nil || !strings.Contains(err.Error(), "singular") {
+		t.Fatalf("TestBinaryCurveValidation: b = 0 returned %v", err)
+	}
+}
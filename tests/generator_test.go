@@ -0,0 +1,52 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+func TestCombMatchesMultiply(t *testing.T) {
+	curves := []curve.CurveFp{
+		withoutBackend(curve.Secp256k1),
+		withoutBackend(curve.Prime256v1),
+		curve.Secp384r1,
+		curve.Secp521r1,
+		curve.BrainpoolP256r1,
+		curve.BrainpoolP384t1,
+		curve.Sm2p256v1,
+		curve.Gost256B,
+		curve.Gost512C,
+	}
+	for _, c := range curves {
+		scalars := []*big.Int{
+			big.NewInt(1),
+			big.NewInt(2),
+			big.NewInt(15),
+			big.NewInt(16),
+			big.NewInt(17),
+			big.NewInt(-5),
+			new(big.Int).Sub(c.N, big.NewInt(1)),
+			new(big.Int).Sub(c.N, big.NewInt(16)),
+			new(big.Int).Add(c.N, big.NewInt(3)),
+			new(big.Int).Lsh(big.NewInt(1), uint(c.NBitLength-1)),
+			new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(c.NBitLength-1)), big.NewInt(1)),
+		}
+		for i := 0; i < 5; i++ {
+			scalars = append(scalars, utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1))))
+		}
+
+		for _, k := range scalars {
+			if !samePoint(c.MultiplyGenerator(k), c.Multiply(c.G, k)) {
+				t.Fatalf("TestCombMatchesMultiply: %v*G differs on %v", k, c.Name)
+			}
+		}
+		for _, k := range []*big.Int{big.NewInt(0), c.N} {
+			if !c.MultiplyGenerator(k).IsAtInfinity() {
+				t.Fatalf("TestCombMatchesMultiply: %v*G isn't the point at infinity on %v", k, c.Name)
+			}
+		}
+	}
+}
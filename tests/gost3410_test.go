@@ -0,0 +1,221 @@
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/gost3410"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/signature"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+const gostMessage = "Balance sheet 2026-10\n"
+
+// Signed with Nettle's gostdsa_sign on the gc256b (paramSetB) curve over the Streebog-256 digest of gostMessage
+const (
+	gostNettleSecret = "7710dd9b52b7d6a66755e08e2a90a17cf5fc9cd6d60b7b2a584ab6c1b632102c"
+	gostNettleR      = "b22b95c980ed1a8c44cdcfeb098edd561304fb2dcb6f12dcf2cb5789021a18be"
+	gostNettleS      = "1899ffe3577e9b4c58999dd6cb33f839d678dd6da3b6ceda6a7069295668a32c"
+)
+
+var gostCurves = []curve.CurveFp{
+	curve.Gost256A,
+	curve.Gost256B,
+	curve.Gost256C,
+	curve.Gost256D,
+	curve.Gost512A,
+	curve.Gost512B,
+	curve.Gost512C,
+}
+
+func TestStreebog(t *testing.T) {
+	// GOST R 34.11-2012 example M1, then an empty and a multi-block message (Nettle)
+	vectors := []struct {
+		message   string
+		digest512 string
+		digest256 string
+	}{
+		{
+			"012345678901234567890123456789012345678901234567890123456789012",
+			"1b54d01a4af5b9d5cc3d86d68d285462b19abc2475222f35c085122be4ba1ffa00ad30f8767b3a82384c6574f024c311e2a481332b08ef7f41797891c1646f48",
+			"9d151eefd8590b89daa6ba6cb74af9275dd051026bb149a452fd84e5e57b5500",
+		},
+		{
+			"",
+			"8e945da209aa869f0455928529bcae4679e9873ab707b55315f56ceb98bef0a7362f715528356ee83cda5f2aac4c6ad2ba3a715c1bcd81cb8e9f90bf4c1c1a8a",
+			"3f539a213e97c802cc229d474c6aa32a825a360b2a933a949fd925208d9ce1bb",
+		},
+		{
+			strings.Repeat("x", 200),
+			"fa94888021137849f813d803a0905e7409b4a31f22fbf46e38cd0fe0c25981903e6939ca1f9908db6cc6928c11a1032e3e6c472256f5172d272487239776f0be",
+			"772df2ca6f19c46961585ad93eda4ebcb6fbd367d09bc21830c0a4bb1f1cdaf2",
+		},
+	}
+	for _, vector := range vectors {
+		for _, expected := range []string{vector.digest512, vector.digest256} {
+			h := utils.Streebog512()
+			if len(expected) == 64 {
+				h = utils.Streebog256()
+			}
+			h.Write([]byte(vector.message[:len(vector.message)/3]))
+			h.Sum(nil)
+			h.Write([]byte(vector.message[len(vector.message)/3:]))
+			if digest := fmt.Sprintf("%x", h.Sum(nil)); digest != expected {
+				t.Fatalf("Streebog-%d of %d bytes: expected %s, got %s", 8*h.Size(), len(vector.message), expected, digest)
+			}
+		}
+	}
+}
+
+func TestGost3410StandardExample(t *testing.T) {
+	// GOST R 34.10-2012 Appendix A.1 (also RFC 7091 §7.1), on the 256-bit test curve
+	exampleCurve := curve.New(
+		"gost-3410-2012-256-example",
+		"0x0000000000000000000000000000000000000000000000000000000000000007",
+		"0x5fbff498aa938ce739b8e022fbafef40563f6e6a3472fc2a514c0ce9dae23b7e",
+		"0x8000000000000000000000000000000000000000000000000000000000000431",
+		"0x8000000000000000000000000000000150fe8a1892976154c59cfc193accf5b3",
+		"0x0000000000000000000000000000000000000000000000000000000000000002",
+		"0x08e2a8a0e65147d4bd6316030e16d19c85c97f0a9ca267122b96abbcea7e8fc8",
+		[]int64{1, 2, 643, 2, 2, 35, 0},
+		"",
+	)
+	privateKey := privatekey.New(exampleCurve, utils.IntFromHex("7a929ade789bb9be10ed359dd39a72c11b60961f49397eee1d19ce9891ec3b28"))
+	publicKey := privateKey.PublicKey()
+
+	if publicKey.Point.X.Cmp(utils.IntFromHex("7f2b49e270db6d90d8595bec458b50c58585ba1d4e9b788f6689dbd8e56fd80b")) != 0 ||
+		publicKey.Point.Y.Cmp(utils.IntFromHex("26f1b489d6701dd185c8413a977b3cbbaf64d1c593d26627dffb101a87ff77da")) != 0 {
+		t.Fatal("TestGost3410StandardExample: wrong public key")
+	}
+
+	// The example gives e = alpha mod q directly; the digest is alpha in little-endian byte order
+	digest := utils.ByteStringFromHex("2dfbc1b372d89a1188c09c52e0eec61fce52032ab1022e8e67ece6672b043ee5")
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	sig := signature.New(
+		*utils.IntFromHex("41aa28d2f1ab148280cd9ed56feda41974053554a42767b83ad043fd39dc0493"),
+		*utils.IntFromHex("01456c64ba4642a1653c235a98a60249bcd6d3f746b631df928014f6c5bf9c40"),
+	)
+	if !gost3410.VerifyHash(digest, sig, &publicKey) {
+		t.Fatal("TestGost3410StandardExample: standard signature didn't verify")
+	}
+	digest[0] ^= 1
+	if gost3410.VerifyHash(digest, sig, &publicKey) {
+		t.Fatal("TestGost3410StandardExample: signature verified a different digest")
+	}
+	if !gost3410.VerifyHash(digest, gost3410.SignHash(digest, &privateKey), &publicKey) {
+		t.Fatal("TestGost3410StandardExample: own signature didn't verify")
+	}
+}
+
+func TestGost3410NettleSignature(t *testing.T) {
+	privateKey := privatekey.New(curve.Gost256B, utils.IntFromHex(gostNettleSecret))
+	publicKey := privateKey.PublicKey()
+	sig := signature.New(*utils.IntFromHex(gostNettleR), *utils.IntFromHex(gostNettleS))
+
+	if !gost3410.Verify(gostMessage, sig, &publicKey) {
+		t.Fatal("TestGost3410NettleSignature: Nettle signature didn't verify")
+	}
+	if gost3410.Verify(gostMessage+".", sig, &publicKey) {
+		t.Fatal("TestGost3410NettleSignature: signature verified a different message")
+	}
+	if gost3410.Verify(gostMessage, sig, &publicKey, utils.Streebog512) {
+		t.Fatal("TestGost3410NettleSignature: signature verified with a different hash")
+	}
+
+	encoded := gost3410.ToBytes(sig, curve.Gost256B)
+	if !bytes.Equal(encoded, utils.ByteStringFromHex(gostNettleS+gostNettleR)) {
+		t.Fatalf("TestGost3410NettleSignature: wrong s || r encoding %x", encoded)
+	}
+	if !gost3410.Verify(gostMessage, gost3410.FromBytes(encoded), &publicKey) {
+		t.Fatal("TestGost3410NettleSignature: decoded signature didn't verify")
+	}
+	assertPanics(t, "FromBytes with an odd length", func() {
+		gost3410.FromBytes(encoded[1:])
+	})
+}
+
+func TestGost3410SignAndVerify(t *testing.T) {
+	for _, c := range gostCurves {
+		privateKey := privatekey.FromPem(privatekey.New(c).ToPem())
+		publicKey := publickey.FromPem(privateKey.PublicKey().ToPem())
+		if privateKey.Curve.Name != c.Name || publicKey.Curve.Name != c.Name {
+			t.Fatalf("TestGost3410SignAndVerify: %s key read on curve %s", c.Name, privateKey.Curve.Name)
+		}
+
+		sig := signature.FromDer(gost3410.Sign(gostMessage, &privateKey).ToDer())
+		if !gost3410.Verify(gostMessage, sig, &publicKey) {
+			t.Fatalf("TestGost3410SignAndVerify: signature on %s didn't verify", c.Name)
+		}
+		if gost3410.Verify(gostMessage+".", sig, &publicKey) {
+			t.Fatalf("TestGost3410SignAndVerify: signature on %s verified a different message", c.Name)
+		}
+		if len(gost3410.ToBytes(sig, c)) != 2*c.Length() {
+			t.Fatalf("TestGost3410SignAndVerify: wrong s || r length on %s", c.Name)
+		}
+
+		sig = gost3410.Sign(gostMessage, &privateKey, utils.Sha256)
+		if !gost3410.Verify(gostMessage, sig, &publicKey, utils.Sha256) {
+			t.Fatalf("TestGost3410SignAndVerify: SHA-256 signature on %s didn't verify", c.Name)
+		}
+	}
+}
+
+func TestGost3410SecretRange(t *testing.T) {
+	for _, secret := range []*big.Int{big.NewInt(0), big.NewInt(-1), curve.Gost256B.N} {
+		privateKey := privatekey.New(curve.Gost256B, secret)
+		assertPanics(t, fmt.Sprintf("gost3410.Sign with secret %v", secret), func() {
+			gost3410.Sign(gostMessage, &privateKey)
+		})
+		assertPanics(t, fmt.Sprintf("gost3410.SignHash with secret %v", secret), func() {
+			gost3410.SignHash(make([]byte, 32), &privateKey)
+		})
+	}
+}
+
+func TestGost3410Keys(t *testing.T) {
+	for _, c := range gostCurves {
+		privateKey := privatekey.New(c)
+		publicKey := privateKey.PublicKey()
+
+		readPrivateKey := gost3410.PrivateKeyFromPem(gost3410.PrivateKeyToPem(privateKey))
+		if readPrivateKey.Curve.Name != c.Name || readPrivateKey.Secret.Cmp(privateKey.Secret) != 0 {
+			t.Fatalf("TestGost3410Keys: the %s private key doesn't round trip", c.Name)
+		}
+		readPublicKey := gost3410.PublicKeyFromPem(gost3410.PublicKeyToPem(publicKey))
+		if readPublicKey.Curve.Name != c.Name || !samePoint(readPublicKey.Point, publicKey.Point) {
+			t.Fatalf("TestGost3410Keys: the %s public key doesn't round trip", c.Name)
+		}
+
+		// the key is an OCTET STRING of x || y, each little-endian, at the end of the BIT STRING
+		der := gost3410.PublicKeyToDer(publicKey)
+		length := c.Length()
+		x := der[len(der)-2*length : len(der)-length]
+		expected := publicKey.Point.X.FillBytes(make([]byte, length))
+		for i := range x {
+			if x[i] != expected[length-1-i] {
+				t.Fatalf("TestGost3410Keys: x isn't little-endian in the %s public key", c.Name)
+			}
+		}
+	}
+
+	// RFC 9215 SubjectPublicKeyInfo header: id-tc26-gost3410-12-256 with paramSetA, and
+	// an OCTET STRING of 64 bytes in the BIT STRING
+	der := gost3410.PublicKeyToDer(privatekey.New(curve.Gost256A).PublicKey())
+	header := utils.ByteStringFromHex("305e301706082a85030701010101300b06092a8503070102010101034300044" + "0")
+	if !bytes.HasPrefix(der, header) {
+		t.Fatalf("TestGost3410Keys: wrong paramSetA SubjectPublicKeyInfo header %x", der[:len(header)])
+	}
+
+	// the id-ecPublicKey encoding isn't a GOST key
+	assertPanics(t, "gost3410.PublicKeyFromDer on an id-ecPublicKey key", func() {
+		gost3410.PublicKeyFromDer(privatekey.New(curve.Gost256A).PublicKey().ToDer())
+	})
+}
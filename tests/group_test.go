@@ -0,0 +1,56 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+func TestGroupLaws(t *testing.T) {
+	for _, c := range []curve.CurveFp{curve.Secp256k1, curve.Prime256v1, curve.Secp521r1, curve.BrainpoolP256r1, curve.Gost256A, curve.Sect283k1} {
+		a := utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1)))
+		b := utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1)))
+		p, q := c.ScalarBaseMult(a), c.ScalarBaseMult(b)
+		identity := c.Identity()
+
+		if !c.EqualPoints(c.Add(p, q), c.ScalarBaseMult(new(big.Int).Add(a, b))) || !c.EqualPoints(c.Add(p, q), c.Add(q, p)) {
+			t.Fatalf("TestGroupLaws: aG + bG isn't (a + b)G on %v", c.Name)
+		}
+		if !c.EqualPoints(c.Add(p, p), c.ScalarMult(p, big.NewInt(2))) {
+			t.Fatalf("TestGroupLaws: P + P isn't 2P on %v", c.Name)
+		}
+		if !c.EqualPoints(c.Add(p, identity), p) || !c.EqualPoints(c.Add(identity, p), p) || !c.Add(identity, identity).IsAtInfinity() {
+			t.Fatalf("TestGroupLaws: the identity isn't neutral on %v", c.Name)
+		}
+		if !c.Add(p, c.Neg(p)).IsAtInfinity() || !c.Contains(c.Neg(p)) || !c.Neg(identity).IsAtInfinity() {
+			t.Fatalf("TestGroupLaws: P + (-P) isn't the identity on %v", c.Name)
+		}
+		if !c.EqualPoints(c.Neg(p), c.ScalarBaseMult(new(big.Int).Sub(c.N, a))) || !c.EqualPoints(c.Neg(c.Neg(p)), p) {
+			t.Fatalf("TestGroupLaws: -aG isn't (N - a)G on %v", c.Name)
+		}
+		if c.EqualPoints(p, q) || c.EqualPoints(p, identity) || !c.EqualPoints(identity, c.ScalarMult(p, c.N)) {
+			t.Fatalf("TestGroupLaws: wrong point equality on %v", c.Name)
+		}
+
+		points := []point.Point{p, q, c.G, identity, c.Neg(q)}
+		scalars := []*big.Int{big.NewInt(3), a, b, big.NewInt(5), big.NewInt(-2)}
+		expected := identity
+		for i := range points {
+			expected = c.Add(expected, c.ScalarMult(points[i], scalars[i]))
+		}
+		for n := len(points); n >= 0; n-- {
+			if !c.EqualPoints(c.MultiScalarMult(points[:n], scalars[:n]), expected) {
+				t.Fatalf("TestGroupLaws: wrong multi-scalar multiplication of %v points on %v", n, c.Name)
+			}
+			if n > 0 {
+				expected = c.Add(expected, c.Neg(c.ScalarMult(points[n-1], scalars[n-1])))
+			}
+		}
+		assertPanics(t, "MultiScalarMult with more points than scalars", func() {
+			c.MultiScalarMult(points, scalars[:2])
+		})
+	}
+}
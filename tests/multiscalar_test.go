@@ -0,0 +1,66 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	ecmath "github.com/starkbank/ecdsa-go/v2/ellipticcurve/math"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// The points are j*G, so the sum of k_j*(j*G) is (the sum of k_j*j)*G
+func TestMultiScalarMult(t *testing.T) {
+	curves := []curve.CurveFp{
+		withoutBackend(curve.Secp256k1),
+		curve.Secp384r1,
+		curve.BrainpoolP256r1,
+		curve.Gost256A,
+	}
+	for _, c := range curves {
+		params := ecmath.MultiScalarMultParams{A: c.A, P: c.P, N: c.N, GLV: c.GLVParams}
+		// Straus below 64 terms, Pippenger above, with or without the GLV split
+		for _, n := range []int{0, 1, 2, 7, 40, 100} {
+			points := make([]point.Point, n)
+			scalars := make([]*big.Int, n)
+			multiple := c.Identity()
+			total := big.NewInt(0)
+			for j := range points {
+				multiple = c.Add(multiple, c.G)
+				points[j] = multiple
+				scalars[j] = utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1)))
+				switch j % 10 {
+				case 3:
+					scalars[j] = big.NewInt(0)
+				case 4:
+					scalars[j] = new(big.Int).Neg(scalars[j])
+				case 5:
+					scalars[j] = new(big.Int).Add(scalars[j], c.N)
+				case 6:
+					// cancels the term before it
+					points[j] = c.Neg(points[j-1])
+					scalars[j] = scalars[j-1]
+				case 7:
+					points[j] = c.Identity()
+				}
+				switch {
+				case j%10 == 6:
+					total.Sub(total, new(big.Int).Mul(scalars[j], big.NewInt(int64(j))))
+				case !points[j].IsAtInfinity():
+					total.Add(total, new(big.Int).Mul(scalars[j], big.NewInt(int64(j+1))))
+				}
+			}
+			expected := c.MultiplyGenerator(total)
+			if !samePoint(ecmath.MultiScalarMult(points, scalars, params), expected) {
+				t.Fatalf("TestMultiScalarMult: wrong sum of %v terms on %v", n, c.Name)
+			}
+			if !samePoint(c.MultiScalarMult(points, scalars), expected) {
+				t.Fatalf("TestMultiScalarMult: CurveFp.MultiScalarMult differs on %v terms on %v", n, c.Name)
+			}
+		}
+		assertPanics(t, "MultiScalarMult with more scalars than points", func() {
+			ecmath.MultiScalarMult([]point.Point{c.G}, []*big.Int{big.NewInt(1), big.NewInt(2)}, params)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package tests
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/openpgp"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+const pgpMessage = "Statement 2026-10\n- credit 100.00\nbalance   \n"
+
+// gpg --quick-gen-key "Statements <statements@partner.com.br>" nistp256 sign && gpg --armor --export
+const pgpPublicKey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mFIEatV61xMIKoZIzj0DAQcCAwRYvTsVYq2D92XtImoOXuqpLMXARTSfzdARnxzE
+gxx+njT6kpclERYjnhr2Ucl6/4lp6VPc+nMCu916IvDp0C8EtCZTdGF0ZW1lbnRz
+IDxzdGF0ZW1lbnRzQHBhcnRuZXIuY29tLmJyPoiQBBMTCAA4FiEEqX5GcSOOFlqO
+YlV1QWFJ29WKTasFAmrVetcCGwMFCwkIBwIGFQoJCAsCBBYCAwECHgECF4AACgkQ
+QWFJ29WKTatTZAD/YdPFQHkVelDKFmH74qy2DgwSxOtHvnHdqEUNzEhNNMYA/0ze
+eXUPFg7G144NWtfmOp8zV7fwME3Y/1KuIgk0mnM9
+=mRna
+-----END PGP PUBLIC KEY BLOCK-----
+`
+
+const pgpFingerprint = "a97e4671238e165a8e625575416149dbd58a4dab"
+
+// gpg --armor --detach-sign message.txt
+const pgpSignature = `-----BEGIN PGP SIGNATURE-----
+
+iJAEABMIADgWIQSpfkZxI44WWo5iVXVBYUnb1YpNqwUCatV61xocc3RhdGVtZW50
+c0BwYXJ0bmVyLmNvbS5icgAKCRBBYUnb1YpNq9s/AP49U2nG4rpQqOWXoUqFfEM/
+ylocSJnXhWQEBmpLL+JKMwEA2IQWMRdGFih+x2NKvcx6m5K+ono2cZcPY+jcUekT
+62s=
+=qJmm
+-----END PGP SIGNATURE-----
+`
+
+// gpg --textmode --armor --detach-sign message.txt
+const pgpTextSignature = `-----BEGIN PGP SIGNATURE-----
+
+iHUEARMIAB0WIQSpfkZxI44WWo5iVXVBYUnb1YpNqwUCatV7jAAKCRBBYUnb1YpN
+qww8AQDe9Os/wEnuw+aTIkvLMWA/iCt80hZa9paO9sXUV/HU6QEAoM8qhFEA47kT
+du8jTpxAjpLwzFQhnQUwPsfck8ppLCo=
+=Atrl
+-----END PGP SIGNATURE-----
+`
+
+// gpg --clearsign message.txt (note the dash escape and the unsigned trailing spaces)
+const pgpCleartext = `-----BEGIN PGP SIGNED MESSAGE-----
+Hash: SHA256
+
+Statement 2026-10
+- - credit 100.00
+` + "balance   \n" + `-----BEGIN PGP SIGNATURE-----
+
+iJAEARMIADgWIQSpfkZxI44WWo5iVXVBYUnb1YpNqwUCatV61xocc3RhdGVtZW50
+c0BwYXJ0bmVyLmNvbS5icgAKCRBBYUnb1YpNq+9BAPsHpyVTr8kZA5NcREB/dyaO
+wGGftje92ZHGYTF1HL2MXwEA4d3xpx1hSNcoqfoqQ/l94PNeH/evjU4NqPmC0Xuj
+y/Q=
+=WmX1
+-----END PGP SIGNATURE-----
+`
+
+// gpg --quick-gen-key "Subkeys <subkeys@partner.com.br>" nistp256 cert &&
+// gpg --quick-add-key <fingerprint> nistp256/ecdsa sign && gpg --armor --export
+const pgpPublicKeyWithSubkey = `-----BEGIN PGP PUBLIC KEY BLOCK-----
+
+mFIEatV7jBMIKoZIzj0DAQcCAwTuvXfgTI/e2qN9pGTiSVV56PeAdD4P3EJbRemB
+PTz5g1zai/vfV14equsZGtjLvqgw7fxKqW//myA7mLkzz9cStCBTdWJrZXlzIDxz
+dWJrZXlzQHBhcnRuZXIuY29tLmJyPoiWBBMTCAA+FiEEEbR3ULJtoCnof9PCpYj8
+0B3eXa8FAmrVe4wCGwEFCQPCZwAFCwkIBwIGFQoJCAsCBBYCAwECHgECF4AACgkQ
+pYj80B3eXa9EHAD7B2fvd6NYlvPwueegn+zu6z4F/E0QHLaL5JV2HoNcUwMBAKm+
+mq25viDuYiWv3aUzWhqh+XouT1GQQrCgJPGq32DVuFIEatV7kBMIKoZIzj0DAQcC
+AwQzqIUA3NG7sjAEQEWDyvuevTKuxce34XRrPiWm4i9tCDQSGZ/sCEtyAzkR/IIC
+MuOvUqUaJAQpA0zasKINe98fiO8EGBMIACAWIQQRtHdQsm2gKeh/08KliPzQHd5d
+rwUCatV7kAIbAgCBCRCliPzQHd5dr3YgBBkTCAAdFiEEAdXJVVgTbnDjGg0tzRpN
+1YfkRKEFAmrVe5AACgkQzRpN1YfkRKEC1QEA5cYzdxRq3+vyrVpqXFAuDSNT0HA/
+DuzBXQ9qE3jATGEA/2oJvCCTYe3QJgm4yMCs5WSEdfMZMxF4FWn0eKZJWiZDSw4A
+/ApYlKYXAENfXyHl4EKnPQO5Ojl+2LWEanjAozxlcuEPAP43L45M3kjC7ooo8h+d
+KEmb72C5qsaKCH3Y8KS8VOMBTQ==
+=y3MZ
+-----END PGP PUBLIC KEY BLOCK-----
+`
+
+const pgpSubkeyFingerprint = "01d5c95558136e70e31a0d2dcd1a4dd587e444a1"
+
+// gpg --armor --detach-sign -u subkeys@partner.com.br message.txt
+const pgpSubkeySignature = `-----BEGIN PGP SIGNATURE-----
+
+iI0EABMIADUWIQQB1clVWBNucOMaDS3NGk3Vh+REoQUCatV7kBccc3Via2V5c0Bw
+YXJ0bmVyLmNvbS5icgAKCRDNGk3Vh+REocVuAP9BSylWl8gz0MH/TCU7s8Yb1Gy3
+nd6fRLe4QChQjhLr6gEAwl6A65Qay0z6KRR8Mp2Io5pDhSvt7ZnYSlkq4ucYUYU=
+=TWJV
+-----END PGP SIGNATURE-----
+`
+
+func TestPgpPublicKeyFromGpg(t *testing.T) {
+	keys := openpgp.KeysFromArmor(pgpPublicKey)
+	if len(keys) != 1 {
+		t.Fatalf("TestPgpPublicKeyFromGpg: expected 1 key, got %d", len(keys))
+	}
+	key := keys[0]
+	if hex.EncodeToString(key.Fingerprint()) != pgpFingerprint {
+		t.Fatalf("TestPgpPublicKeyFromGpg: wrong fingerprint %x", key.Fingerprint())
+	}
+	if hex.EncodeToString(key.KeyId()) != pgpFingerprint[24:] {
+		t.Fatalf("TestPgpPublicKeyFromGpg: wrong key ID %x", key.KeyId())
+	}
+	if !curve.IsOidEqual(key.PublicKey.Curve.Oid, curve.Prime256v1.Oid) {
+		t.Fatal("TestPgpPublicKeyFromGpg: wrong curve")
+	}
+	if len(key.UserIds) != 1 || key.UserIds[0] != "Statements <statements@partner.com.br>" {
+		t.Fatalf("TestPgpPublicKeyFromGpg: unexpected user IDs %v", key.UserIds)
+	}
+}
+
+func TestPgpVerifyGpgSignatures(t *testing.T) {
+	key := openpgp.KeysFromArmor(pgpPublicKey)[0]
+
+	sig := openpgp.SignatureFromArmor(pgpSignature)
+	if sig.Type != openpgp.BinarySignature || hex.EncodeToString(sig.IssuerFingerprint) != pgpFingerprint {
+		t.Fatal("TestPgpVerifyGpgSignatures: unexpected signature metadata")
+	}
+	if !openpgp.Verify(pgpMessage, sig, key) {
+		t.Fatal("TestPgpVerifyGpgSignatures failed on the binary signature")
+	}
+	if openpgp.Verify(strings.Replace(pgpMessage, "100.00", "900.00", 1), sig, key) {
+		t.Fatal("TestPgpVerifyGpgSignatures accepted a tampered message")
+	}
+
+	textSig := openpgp.SignatureFromArmor(pgpTextSignature)
+	if textSig.Type != openpgp.TextSignature {
+		t.Fatal("TestPgpVerifyGpgSignatures: expected a text signature")
+	}
+	if !openpgp.Verify(pgpMessage, textSig, key) {
+		t.Fatal("TestPgpVerifyGpgSignatures failed on the text signature")
+	}
+	if !openpgp.Verify(strings.ReplaceAll(pgpMessage, "\n", "\r\n"), textSig, key) {
+		t.Fatal("TestPgpVerifyGpgSignatures: text signatures should ignore line endings")
+	}
+
+	text, ok := openpgp.VerifyCleartext(pgpCleartext, key)
+	if !ok {
+		t.Fatal("TestPgpVerifyGpgSignatures failed on the cleartext signature")
+	}
+	if text != strings.TrimSuffix(pgpMessage, "\n") {
+		t.Fatalf("TestPgpVerifyGpgSignatures: unexpected cleartext %q", text)
+	}
+	if _, ok := openpgp.VerifyCleartext(strings.Replace(pgpCleartext, "100.00", "900.00", 1), key); ok {
+		t.Fatal("TestPgpVerifyGpgSignatures accepted a tampered cleartext")
+	}
+}
+
+func TestPgpSubkeySignature(t *testing.T) {
+	keys := openpgp.KeysFromArmor(pgpPublicKeyWithSubkey)
+	if len(keys) != 2 || !keys[1].IsSubkey {
+		t.Fatalf("TestPgpSubkeySignature: expected a primary key and a subkey, got %d keys", len(keys))
+	}
+
+	sig := openpgp.SignatureFromArmor(pgpSubkeySignature)
+	key, ok := openpgp.FindKey(keys, sig)
+	if !ok || hex.EncodeToString(key.Fingerprint()) != pgpSubkeyFingerprint {
+		t.Fatal("TestPgpSubkeySignature: signature should be issued by the subkey")
+	}
+	if !openpgp.Verify(pgpMessage, sig, key) {
+		t.Fatal("TestPgpSubkeySignature failed")
+	}
+	if openpgp.Verify(pgpMessage, sig, keys[0]) {
+		t.Fatal("TestPgpSubkeySignature verified with the primary key")
+	}
+}
+
+func TestPgpSignAndVerify(t *testing.T) {
+	privateKey := privatekey.New(curve.Prime256v1)
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	keys := openpgp.KeysFromArmor(openpgp.ExportPublicKey(&privateKey, createdAt, "Treasury <treasury@example.com>"))
+	if len(keys) != 1 || len(keys[0].UserIds) != 1 || !keys[0].CreatedAt.Equal(createdAt) {
+		t.Fatal("TestPgpSignAndVerify: exported key didn't round trip")
+	}
+	key := keys[0]
+	if key.PublicKey.ToString(false) != privateKey.PublicKey().ToString(false) {
+		t.Fatal("TestPgpSignAndVerify: exported the wrong public key")
+	}
+
+	sig := openpgp.Sign(pgpMessage, &privateKey, createdAt)
+	parsed := openpgp.SignatureFromBytes(sig.ToBytes())
+	if !openpgp.Verify(pgpMessage, parsed, key) || !openpgp.Verify(pgpMessage, openpgp.SignatureFromArmor(sig.ToArmor()), key) {
+		t.Fatal("TestPgpSignAndVerify failed on the binary signature")
+	}
+	if hex.EncodeToString(parsed.IssuerKeyId) != hex.EncodeToString(key.KeyId()) {
+		t.Fatal("TestPgpSignAndVerify: wrong issuer key ID")
+	}
+
+	sig384 := openpgp.Sign(pgpMessage, &privateKey, createdAt, utils.Sha384)
+	if !openpgp.Verify(pgpMessage, openpgp.SignatureFromBytes(sig384.ToBytes()), key) {
+		t.Fatal("TestPgpSignAndVerify failed with sha384")
+	}
+
+	textSig := openpgp.SignText(pgpMessage, &privateKey, createdAt)
+	if !openpgp.Verify(strings.ReplaceAll(pgpMessage, "\n", "\r\n"), textSig, key) {
+		t.Fatal("TestPgpSignAndVerify failed on the text signature")
+	}
+
+	cleartext := openpgp.SignCleartext(pgpMessage, &privateKey, createdAt)
+	if !strings.Contains(cleartext, "\n- - credit 100.00\n") {
+		t.Fatalf("TestPgpSignAndVerify: missing dash escape in %s", cleartext)
+	}
+	text, ok := openpgp.VerifyCleartext(cleartext, key)
+	if !ok || text != strings.TrimSuffix(pgpMessage, "\n") {
+		t.Fatalf("TestPgpSignAndVerify failed on the cleartext signature: %q", text)
+	}
+
+	otherKey := privatekey.New(curve.Prime256v1)
+	other := openpgp.NewKey(otherKey.PublicKey(), createdAt)
+	if openpgp.Verify(pgpMessage, sig, other) {
+		t.Fatal("TestPgpSignAndVerify verified with the wrong key")
+	}
+}
+
+// RFC 6637 §5 pairs P-256, P-384 and P-521 with SHA2-256, SHA2-384 and SHA2-512 (IDs 8, 9, 10)
+func TestPgpDefaultHash(t *testing.T) {
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for hashAlgorithm, c := range map[byte]curve.CurveFp{8: curve.Prime256v1, 9: curve.Secp384r1, 10: curve.Secp521r1} {
+		privateKey := privatekey.New(c)
+		sig := openpgp.Sign(pgpMessage, &privateKey, createdAt)
+		if sig.HashAlgorithm != hashAlgorithm {
+			t.Fatalf("TestPgpDefaultHash: hash algorithm %v on %v, expected %v", sig.HashAlgorithm, c.Name, hashAlgorithm)
+		}
+		if !openpgp.Verify(pgpMessage, openpgp.SignatureFromBytes(sig.ToBytes()), openpgp.NewKey(privateKey.PublicKey(), createdAt)) {
+			t.Fatalf("TestPgpDefaultHash: the signature doesn't verify on %v", c.Name)
+		}
+	}
+}
+
+func TestPgpMalformedInput(t *testing.T) {
+	assertPanics(t, "missing armor", func() {
+		openpgp.SignatureFromArmor("not a signature")
+	})
+	assertPanics(t, "bad checksum", func() {
+		openpgp.SignatureFromArmor(strings.Replace(pgpSignature, "=qJmm", "=qJmn", 1))
+	})
+	assertPanics(t, "truncated packet", func() {
+		data := openpgp.SignatureFromArmor(pgpSignature).ToBytes()
+		openpgp.SignatureFromBytes(data[:len(data)-10])
+	})
+}
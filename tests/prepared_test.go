@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/point"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/publickey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/utils"
+)
+
+// preparedCurves cover the backends, the generic GLV and JSF paths, an isomorphic
+// A = -3 curve and a binary curve
+var preparedCurves = []curve.CurveFp{
+	curve.Secp256k1,
+	curve.Prime256v1,
+	withoutBackend(curve.Secp256k1),
+	withoutBackend(curve.Prime256v1),
+	curve.Secp384r1,
+	curve.BrainpoolP256r1,
+	curve.Gost256A,
+	curve.Sect233k1,
+}
+
+func TestMultiplyGeneratorAndAdd(t *testing.T) {
+	for _, c := range preparedCurves {
+		q := c.MultiplyGenerator(big.NewInt(987654321))
+		prepared := c.Prepare(q)
+		scalars := []*big.Int{
+			big.NewInt(0),
+			big.NewInt(1),
+			big.NewInt(-7),
+			new(big.Int).Sub(c.N, big.NewInt(1)),
+			utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1))),
+			utils.Between(big.NewInt(1), new(big.Int).Sub(c.N, big.NewInt(1))),
+		}
+		for _, k1 := range scalars {
+			for _, k2 := range scalars {
+				if !samePoint(c.MultiplyGeneratorAndAdd(k1, prepared, k2), c.MultiplyAndAdd(c.G, k1, q, k2)) {
+					t.Fatalf("TestMultiplyGeneratorAndAdd: %v*G + %v*Q differs on %v", k1, k2, c.Name)
+				}
+			}
+		}
+
+		// G + G, G - G and the point at infinity
+		if !samePoint(c.MultiplyGeneratorAndAdd(big.NewInt(5), c.Prepare(c.G), big.NewInt(5)), c.MultiplyGenerator(big.NewInt(10))) {
+			t.Fatalf("TestMultiplyGeneratorAndAdd: 5*G + 5*G isn't 10*G on %v", c.Name)
+		}
+		if !c.MultiplyGeneratorAndAdd(big.NewInt(5), c.Prepare(c.G), new(big.Int).Sub(c.N, big.NewInt(5))).IsAtInfinity() {
+			t.Fatalf("TestMultiplyGeneratorAndAdd: 5*G - 5*G isn't the point at infinity on %v", c.Name)
+		}
+		infinity := point.AtInfinity()
+		if !samePoint(c.MultiplyGeneratorAndAdd(big.NewInt(3), c.Prepare(infinity), big.NewInt(4)), c.MultiplyGenerator(big.NewInt(3))) {
+			t.Fatalf("TestMultiplyGeneratorAndAdd: the point at infinity wasn't ignored on %v", c.Name)
+		}
+	}
+}
+
+func TestVerifyPrepared(t *testing.T) {
+	for _, c := range preparedCurves {
+		privateKey := privatekey.New(c)
+		publicKey := privateKey.PublicKey()
+		prepared := publicKey.Precompute()
+		otherKey := privatekey.New(c).PublicKey()
+
+		for i := 0; i < 3; i++ {
+			sig := ecdsa.Sign("message", &privateKey)
+			if !ecdsa.VerifyPrepared("message", sig, &prepared) {
+				t.Fatalf("TestVerifyPrepared: a signature didn't verify on %v", c.Name)
+			}
+			if ecdsa.VerifyPrepared("other message", sig, &prepared) {
+				t.Fatalf("TestVerifyPrepared: a signature of another message verified on %v", c.Name)
+			}
+			otherPrepared := otherKey.Precompute()
+			if ecdsa.VerifyPrepared("message", sig, &otherPrepared) {
+				t.Fatalf("TestVerifyPrepared: a signature verified against another key on %v", c.Name)
+			}
+		}
+
+		sig := ecdsa.Sign("message", &privateKey)
+		sig.S = *new(big.Int).Add(&sig.S, c.N)
+		if ecdsa.VerifyPrepared("message", sig, &prepared) {
+			t.Fatalf("TestVerifyPrepared: an out of range s verified on %v", c.Name)
+		}
+	}
+
+	// the subgroup check runs once, when preparing the key
+	c := curve.Gost256A
+	outside := publickey.PublicKey{Point: pointOutsideSubgroup(c), Curve: c}.Precompute()
+	if outside.InSubgroup() {
+		t.Fatal("TestVerifyPrepared: a point outside the subgroup passed the subgroup check")
+	}
+	privateKey := privatekey.New(c)
+	if ecdsa.VerifyPrepared("message", ecdsa.Sign("message", &privateKey), &outside) {
+		t.Fatal("TestVerifyPrepared: a signature verified against a point outside the subgroup")
+	}
+}
@@ -153,7 +153,6 @@ func TestRejectOffCurvePublicKey(t *testing.T) {
 	offCurvePoint := point.Point{
 		X: new(big.Int).Set(pub.Point.X),
 		Y: new(big.Int).Add(pub.Point.Y, big.NewInt(1)),
-		Z: big.NewInt(0),
 	}
 	offCurveKey := publickey.PublicKey{Point: offCurvePoint, Curve: pub.Curve}
 
@@ -0,0 +1,182 @@
+package tests
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/curve"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/ecdsa"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/privatekey"
+	"github.com/starkbank/ecdsa-go/v2/ellipticcurve/sshagent"
+)
+
+func startAgent(t *testing.T) (*sshagent.Agent, *sshagent.Client) {
+	t.Helper()
+	// t.TempDir paths can exceed the Unix socket path limit
+	dir, err := os.MkdirTemp("", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(dir, "agent.sock")
+	agent := sshagent.New()
+	go agent.ListenAndServe(socket)
+
+	var conn net.Conn
+	for i := 0; i < 100; i++ {
+		if conn, err = net.Dial("unix", socket); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		conn.Close()
+		os.RemoveAll(dir)
+	})
+	return agent, sshagent.NewClient(conn)
+}
+
+func TestSshAgentSign(t *testing.T) {
+	agent, client := startAgent(t)
+	privateKey := privatekey.FromPem(sshPrivateKeyPem)
+	publicKey := privateKey.PublicKey()
+	if err := agent.Add(privateKey, "ci"); err != nil {
+		t.Fatal(err)
+	}
+
+	identities, err := client.List()
+	if err != nil || len(identities) != 1 {
+		t.Fatalf("TestSshAgentSign: unexpected identities %v (%v)", identities, err)
+	}
+	if identities[0].Comment != "ci" || identities[0].PublicKey.ToSsh() != sshPublicKey {
+		t.Fatal("TestSshAgentSign: identity mismatch")
+	}
+
+	challenge := "session challenge"
+	sig, err := client.Sign(identities[0].PublicKey, []byte(challenge))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.Verify(challenge, sig, &publicKey) {
+		t.Fatal("TestSshAgentSign returned an invalid signature")
+	}
+
+	unknownKey := privatekey.New(curve.Prime256v1).PublicKey()
+	if _, err := client.Sign(unknownKey, []byte(challenge)); err == nil {
+		t.Fatal("TestSshAgentSign signed with a key it doesn't hold")
+	}
+}
+
+func TestSshAgentAddAndRemove(t *testing.T) {
+	_, client := startAgent(t)
+	privateKey1 := privatekey.New(curve.Prime256v1)
+	privateKey2 := privatekey.New(curve.Prime256v1)
+
+	if err := client.Add(privateKey1, "first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Add(privateKey2, "second", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if identities, _ := client.List(); len(identities) != 2 {
+		t.Fatalf("TestSshAgentAddAndRemove: expected 2 identities, got %d", len(identities))
+	}
+
+	if err := client.Remove(privateKey1.PublicKey()); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Remove(privateKey1.PublicKey()); err == nil {
+		t.Fatal("TestSshAgentAddAndRemove removed the same key twice")
+	}
+	identities, _ := client.List()
+	if len(identities) != 1 || identities[0].Comment != "second" {
+		t.Fatalf("TestSshAgentAddAndRemove: unexpected identities %v", identities)
+	}
+
+	if err := client.RemoveAll(); err != nil {
+		t.Fatal(err)
+	}
+	if identities, _ := client.List(); len(identities) != 0 {
+		t.Fatal("TestSshAgentAddAndRemove: keys left after RemoveAll")
+	}
+}
+
+func TestSshAgentLock(t *testing.T) {
+	agent, client := startAgent(t)
+	privateKey := privatekey.New(curve.Prime256v1)
+	agent.Add(privateKey, "locked")
+
+	if err := client.Lock([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if identities, _ := client.List(); len(identities) != 0 {
+		t.Fatal("TestSshAgentLock: locked agent listed identities")
+	}
+	if _, err := client.Sign(privateKey.PublicKey(), []byte("data")); err == nil {
+		t.Fatal("TestSshAgentLock: locked agent signed")
+	}
+	if err := client.Unlock([]byte("wrong")); err == nil {
+		t.Fatal("TestSshAgentLock: unlocked with the wrong passphrase")
+	}
+	if err := client.Unlock([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Sign(privateKey.PublicKey(), []byte("data")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSshAgentExpiresKeys(t *testing.T) {
+	agent, client := startAgent(t)
+	agent.Add(privatekey.New(curve.Prime256v1), "short-lived", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if identities, _ := client.List(); len(identities) != 0 {
+		t.Fatal("TestSshAgentExpiresKeys: expired key still listed")
+	}
+}
+
+func TestSshAgentSocketPermissions(t *testing.T) {
+	dir, err := os.MkdirTemp("", "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "agent.sock")
+	go sshagent.New().ListenAndServe(socket)
+
+	var info os.FileInfo
+	for i := 0; i < 100; i++ {
+		if info, err = os.Stat(socket); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Fatalf("TestSshAgentSocketPermissions: the socket has mode %v", info.Mode())
+	}
+	// only the socket is left next to path, without the private directory it was made in
+	var entries []os.DirEntry
+	for i := 0; i < 100; i++ {
+		if entries, err = os.ReadDir(dir); err != nil || len(entries) == 1 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("TestSshAgentSocketPermissions: unexpected entries %v (%v)", entries, err)
+	}
+
+	// an existing path isn't replaced
+	if err := sshagent.New().ListenAndServe(socket); err == nil {
+		t.Fatal("TestSshAgentSocketPermissions: listened on an existing path")
+	}
+}